@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+
+	"github.com/thisausername99/pantry_butler/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// runCommand runs a one-off administrative command instead of the server,
+// e.g. `pantry_butler import-catalog products.csv` or
+// `pantry_butler import-entries -pantry <id> -user <id> [-dry-run] entries.csv` or
+// `pantry_butler import-recipes -user <id> [-dry-run] recipes.md`.
+func runCommand(ctx context.Context, uc *usecase.Usecase, log *zap.Logger, args []string) error {
+	switch args[0] {
+	case "import-catalog":
+		if len(args) != 2 {
+			return errors.New("usage: pantry_butler import-catalog <file.csv>")
+		}
+		return importCatalog(ctx, uc, log, args[1])
+	case "import-entries":
+		return importEntries(ctx, uc, log, args[1:])
+	case "import-recipes":
+		return importRecipes(ctx, uc, log, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func importCatalog(ctx context.Context, uc *usecase.Usecase, log *zap.Logger, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	result, err := uc.ImportProductsCSV(ctx, file)
+	if err != nil {
+		return err
+	}
+	for _, rowErr := range result.Errors {
+		log.Warn("Skipped catalog row", zap.Int("row", rowErr.Row), zap.String("reason", rowErr.Message))
+	}
+	log.Info("Catalog import finished", zap.Int("imported", result.Imported), zap.Int("skipped", len(result.Errors)))
+	return nil
+}
+
+func importEntries(ctx context.Context, uc *usecase.Usecase, log *zap.Logger, args []string) error {
+	flags := flag.NewFlagSet("import-entries", flag.ContinueOnError)
+	pantryID := flags.String("pantry", "", "pantry to import into")
+	userID := flags.String("user", "", "user performing the import; must be an editor of the pantry")
+	format := flags.String("format", "", "csv or json; detected from the file extension when omitted")
+	dryRun := flags.Bool("dry-run", false, "validate and report without writing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *pantryID == "" || *userID == "" || flags.NArg() != 1 {
+		return errors.New("usage: pantry_butler import-entries -pantry <id> -user <id> [-format csv|json] [-dry-run] <file>")
+	}
+	path := flags.Arg(0)
+
+	importFormat := entity.ImportFormat(strings.ToUpper(*format))
+	if *format == "" {
+		detected, err := usecase.ImportFormatFromFilename(path)
+		if err != nil {
+			return err
+		}
+		importFormat = detected
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	ctx = context.WithValue(ctx, "userID", *userID)
+	result, err := uc.ImportPantryEntries(ctx, *pantryID, file, importFormat, *dryRun)
+	if err != nil {
+		return err
+	}
+	for _, rowErr := range result.Errors {
+		log.Warn("Invalid entry row", zap.Int("row", rowErr.Row), zap.String("reason", rowErr.Message))
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d rows are invalid; nothing was imported", len(result.Errors))
+	}
+	log.Info("Entry import finished", zap.Int("imported", result.Imported), zap.Bool("dryRun", result.DryRun))
+	return nil
+}
+
+func importRecipes(ctx context.Context, uc *usecase.Usecase, log *zap.Logger, args []string) error {
+	flags := flag.NewFlagSet("import-recipes", flag.ContinueOnError)
+	userID := flags.String("user", "", "user the imported recipes are credited to")
+	format := flags.String("format", "", "jsonld, markdown or mealmaster; detected from the file extension when omitted")
+	dryRun := flags.Bool("dry-run", false, "validate and report without writing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *userID == "" || flags.NArg() != 1 {
+		return errors.New("usage: pantry_butler import-recipes -user <id> [-format jsonld|markdown|mealmaster] [-dry-run] <file>")
+	}
+	path := flags.Arg(0)
+
+	importFormat := entity.RecipeImportFormat(strings.ToUpper(*format))
+	if *format == "" {
+		detected, err := usecase.RecipeImportFormatFromFilename(path)
+		if err != nil {
+			return err
+		}
+		importFormat = detected
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	ctx = context.WithValue(ctx, "userID", *userID)
+	result, err := uc.ImportRecipes(ctx, file, importFormat, *dryRun)
+	if err != nil {
+		return err
+	}
+	for _, recipeErr := range result.Errors {
+		name := ""
+		if recipeErr.Name != nil {
+			name = *recipeErr.Name
+		}
+		log.Warn("Recipe not imported", zap.Int("index", recipeErr.Index), zap.String("name", name), zap.String("reason", recipeErr.Message))
+	}
+	log.Info("Recipe import finished", zap.Int("imported", result.Imported), zap.Int("failed", len(result.Errors)), zap.Bool("dryRun", result.DryRun))
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d recipes could not be imported", len(result.Errors))
+	}
+	return nil
+}
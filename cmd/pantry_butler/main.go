@@ -41,6 +41,14 @@ func main() {
 	// Setup collections
 	pantryEntryCollection := mongoClient.Database(config.MongoDB.Database).Collection("pantries")
 	recipeCollection := mongoClient.Database(config.MongoDB.Database).Collection("recipes")
+	userCollection := mongoClient.Database(config.MongoDB.Database).Collection("users")
+	inviteCollection := mongoClient.Database(config.MongoDB.Database).Collection("pantry_invites")
+	productCollection := mongoClient.Database(config.MongoDB.Database).Collection("products")
+	shoppingListCollection := mongoClient.Database(config.MongoDB.Database).Collection("shopping_lists")
+	mealPlanCollection := mongoClient.Database(config.MongoDB.Database).Collection("meal_plans")
+	historyCollection := mongoClient.Database(config.MongoDB.Database).Collection("pantry_history")
+	ratingCollection := mongoClient.Database(config.MongoDB.Database).Collection("recipe_ratings")
+	substitutionCollection := mongoClient.Database(config.MongoDB.Database).Collection("ingredient_substitutions")
 
 	// Get port from environment
 	port := os.Getenv("PORT")
@@ -52,11 +60,29 @@ func main() {
 	uc := &usecase.Usecase{
 		Logger: log,
 		RepoWrapper: usecase.RepoWrapper{
-			RecipeRepo: &mongo.RecipeRepo{Collection: recipeCollection, Logger: log},
-			PantryRepo: &mongo.PantryEntryRepo{Collection: pantryEntryCollection, Logger: log},
+			RecipeRepo:       &mongo.RecipeRepo{Collection: recipeCollection, Logger: log},
+			PantryRepo:       &mongo.PantryEntryRepo{Collection: pantryEntryCollection, Logger: log},
+			UserRepo:         &mongo.UserRepo{Collection: userCollection, Logger: log},
+			InviteRepo:       &mongo.InviteRepo{Collection: inviteCollection, Logger: log},
+			ProductRepo:      &mongo.ProductRepo{Collection: productCollection, Logger: log},
+			ShoppingListRepo: &mongo.ShoppingListRepo{Collection: shoppingListCollection, Logger: log},
+			MealPlanRepo:     &mongo.MealPlanRepo{Collection: mealPlanCollection, Logger: log},
+			HistoryRepo:      &mongo.HistoryRepo{Collection: historyCollection, Logger: log},
+			RatingRepo:       &mongo.RatingRepo{Collection: ratingCollection, Logger: log},
+			SubstitutionRepo: &mongo.SubstitutionRepo{Collection: substitutionCollection, Logger: log},
+			Transactor:       mongoClient,
 		},
 	}
 
+	// Run a one-off command instead of the server when one is given
+	if len(os.Args) > 1 {
+		if err := runCommand(context.Background(), uc, log, os.Args[1:]); err != nil {
+			log.Error("Command failed", zap.Error(err))
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Create HTTP server with Gin
 	server := http.NewServer(log, uc)
 
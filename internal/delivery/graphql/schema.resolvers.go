@@ -6,17 +6,172 @@ package graphql
 
 import (
 	"context"
-	"fmt"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/usecase"
 )
 
 // InsertEntry is the resolver for the insertEntry field.
-func (r *mutationResolver) InsertEntry(ctx context.Context, pantryID string, entryInput entity.PantryEntryInput) (bool, error) {
+func (r *mutationResolver) InsertEntry(ctx context.Context, pantryID string, entryInput entity.PantryEntryInput, merge bool) (bool, error) {
+	if merge {
+		_, _, err := r.UseCase.InsertOrMergePantryEntry(ctx, pantryID, &entryInput)
+		return err == nil, err
+	}
 	err := r.UseCase.InsertPantryEntry(ctx, pantryID, &entryInput)
 	return err == nil, err
 }
 
+// UpdateEntry is the resolver for the updateEntry field.
+func (r *mutationResolver) UpdateEntry(ctx context.Context, pantryID string, entryID string, patch entity.PantryEntryPatch) (bool, error) {
+	err := r.UseCase.UpdatePantryEntry(ctx, pantryID, entryID, &patch)
+	return err == nil, err
+}
+
+// ConsumeEntry is the resolver for the consumeEntry field.
+func (r *mutationResolver) ConsumeEntry(ctx context.Context, pantryID string, entryID string, amount float64, unit *string) (*entity.ConsumeResult, error) {
+	return r.UseCase.ConsumePantryEntry(ctx, pantryID, entryID, amount, unit)
+}
+
+// InvitePantryMember is the resolver for the invitePantryMember field.
+func (r *mutationResolver) InvitePantryMember(ctx context.Context, pantryID string, email string, role entity.PantryRole) (*entity.PantryInvite, error) {
+	return r.UseCase.InvitePantryMember(ctx, pantryID, email, role)
+}
+
+// AcceptPantryInvite is the resolver for the acceptPantryInvite field.
+func (r *mutationResolver) AcceptPantryInvite(ctx context.Context, code string) (bool, error) {
+	err := r.UseCase.AcceptPantryInvite(ctx, code)
+	return err == nil, err
+}
+
+// RevokePantryAccess is the resolver for the revokePantryAccess field.
+func (r *mutationResolver) RevokePantryAccess(ctx context.Context, pantryID string, userID string) (bool, error) {
+	err := r.UseCase.RevokePantryAccess(ctx, pantryID, userID)
+	return err == nil, err
+}
+
+// AddStorageLocation is the resolver for the addStorageLocation field.
+func (r *mutationResolver) AddStorageLocation(ctx context.Context, pantryID string, location entity.StorageLocationInput) (*entity.StorageLocation, error) {
+	return r.UseCase.AddStorageLocation(ctx, pantryID, &location)
+}
+
+// RemoveStorageLocation is the resolver for the removeStorageLocation field.
+func (r *mutationResolver) RemoveStorageLocation(ctx context.Context, pantryID string, locationID string) (bool, error) {
+	err := r.UseCase.RemoveStorageLocation(ctx, pantryID, locationID)
+	return err == nil, err
+}
+
+// MoveEntry is the resolver for the moveEntry field.
+func (r *mutationResolver) MoveEntry(ctx context.Context, pantryID string, entryID string, locationID string) (bool, error) {
+	err := r.UseCase.MovePantryEntry(ctx, pantryID, entryID, locationID)
+	return err == nil, err
+}
+
+// TagEntry is the resolver for the tagEntry field.
+func (r *mutationResolver) TagEntry(ctx context.Context, pantryID string, entryID string, tags []string) (bool, error) {
+	err := r.UseCase.TagPantryEntry(ctx, pantryID, entryID, tags)
+	return err == nil, err
+}
+
+// UntagEntry is the resolver for the untagEntry field.
+func (r *mutationResolver) UntagEntry(ctx context.Context, pantryID string, entryID string, tags []string) (bool, error) {
+	err := r.UseCase.UntagPantryEntry(ctx, pantryID, entryID, tags)
+	return err == nil, err
+}
+
+// InsertEntryByBarcode is the resolver for the insertEntryByBarcode field.
+func (r *mutationResolver) InsertEntryByBarcode(ctx context.Context, pantryID string, code string) (*entity.PantryEntry, error) {
+	return r.UseCase.InsertPantryEntryByBarcode(ctx, pantryID, code)
+}
+
+// CreateShoppingList is the resolver for the createShoppingList field.
+func (r *mutationResolver) CreateShoppingList(ctx context.Context, pantryID string, name string) (*entity.ShoppingList, error) {
+	return r.UseCase.CreateShoppingList(ctx, pantryID, name)
+}
+
+// AddShoppingListItem is the resolver for the addShoppingListItem field.
+func (r *mutationResolver) AddShoppingListItem(ctx context.Context, listID string, item entity.ShoppingListItemInput) (*entity.ShoppingListItem, error) {
+	return r.UseCase.AddShoppingListItem(ctx, listID, &item)
+}
+
+// CheckShoppingListItem is the resolver for the checkShoppingListItem field.
+func (r *mutationResolver) CheckShoppingListItem(ctx context.Context, listID string, itemID string, checked bool, addToPantry *entity.AddToPantryInput) (*entity.CheckShoppingItemResult, error) {
+	return r.UseCase.CheckShoppingListItem(ctx, listID, itemID, checked, addToPantry)
+}
+
+// ClearShoppingList is the resolver for the clearShoppingList field.
+func (r *mutationResolver) ClearShoppingList(ctx context.Context, listID string, checkedOnly bool) (bool, error) {
+	err := r.UseCase.ClearShoppingList(ctx, listID, checkedOnly)
+	return err == nil, err
+}
+
+// PlanMeal is the resolver for the planMeal field.
+func (r *mutationResolver) PlanMeal(ctx context.Context, pantryID string, plan entity.MealPlanInput) (*entity.MealPlan, error) {
+	return r.UseCase.PlanMeal(ctx, pantryID, &plan)
+}
+
+// RemoveMealPlan is the resolver for the removeMealPlan field.
+func (r *mutationResolver) RemoveMealPlan(ctx context.Context, planID string) (bool, error) {
+	err := r.UseCase.RemoveMealPlan(ctx, planID)
+	return err == nil, err
+}
+
+// MergeDuplicateEntries is the resolver for the mergeDuplicateEntries field.
+func (r *mutationResolver) MergeDuplicateEntries(ctx context.Context, pantryID string) (*entity.MergeReport, error) {
+	return r.UseCase.MergeDuplicateEntries(ctx, pantryID)
+}
+
+// ImportEntries is the resolver for the importEntries field.
+func (r *mutationResolver) ImportEntries(ctx context.Context, pantryID string, file graphql.Upload, format *entity.ImportFormat, dryRun bool) (*entity.EntryImportResult, error) {
+	if format == nil {
+		detected, err := usecase.ImportFormatFromFilename(file.Filename)
+		if err != nil {
+			return nil, err
+		}
+		format = &detected
+	}
+	return r.UseCase.ImportPantryEntries(ctx, pantryID, file.File, *format, dryRun)
+}
+
+// CreateRecipe is the resolver for the createRecipe field.
+func (r *mutationResolver) CreateRecipe(ctx context.Context, recipe entity.RecipeInput) (*entity.Recipe, error) {
+	return r.UseCase.CreateRecipe(ctx, &recipe)
+}
+
+// UpdateRecipe is the resolver for the updateRecipe field.
+func (r *mutationResolver) UpdateRecipe(ctx context.Context, recipeID string, recipe entity.RecipeInput) (*entity.Recipe, error) {
+	return r.UseCase.UpdateRecipe(ctx, recipeID, &recipe)
+}
+
+// DeleteRecipe is the resolver for the deleteRecipe field.
+func (r *mutationResolver) DeleteRecipe(ctx context.Context, recipeID string) (bool, error) {
+	err := r.UseCase.DeleteRecipe(ctx, recipeID)
+	return err == nil, err
+}
+
+// RateRecipe is the resolver for the rateRecipe field.
+func (r *mutationResolver) RateRecipe(ctx context.Context, recipeID string, stars int, note *string) (*entity.RecipeRating, error) {
+	return r.UseCase.RateRecipe(ctx, recipeID, stars, note)
+}
+
+// CookRecipe is the resolver for the cookRecipe field.
+func (r *mutationResolver) CookRecipe(ctx context.Context, recipeID string, pantryID string, servings *int) (*entity.CookReport, error) {
+	return r.UseCase.CookRecipe(ctx, recipeID, pantryID, servings)
+}
+
+// ImportRecipes is the resolver for the importRecipes field.
+func (r *mutationResolver) ImportRecipes(ctx context.Context, file graphql.Upload, format *entity.RecipeImportFormat, dryRun bool) (*entity.RecipeImportResult, error) {
+	if format == nil {
+		detected, err := usecase.RecipeImportFormatFromFilename(file.Filename)
+		if err != nil {
+			return nil, err
+		}
+		format = &detected
+	}
+	return r.UseCase.ImportRecipes(ctx, file.File, *format, dryRun)
+}
+
 // GetRecipe is the resolver for the getRecipe field.
 func (r *queryResolver) GetRecipes(ctx context.Context) ([]*entity.Recipe, error) {
 	recipes, err := r.UseCase.GetAllRecipes(ctx)
@@ -44,14 +199,49 @@ func (r *queryResolver) GetRecipesByCuisine(ctx context.Context, cuisine string)
 	return result, nil
 }
 
+// SearchRecipes is the resolver for the searchRecipes field.
+func (r *queryResolver) SearchRecipes(ctx context.Context, query string, limit *int, after *string) (*entity.RecipeSearchPage, error) {
+	return r.UseCase.SearchRecipes(ctx, query, limit, after)
+}
+
+// MyRating is the resolver for the myRating field.
+func (r *queryResolver) MyRating(ctx context.Context, recipeID string) (*entity.RecipeRating, error) {
+	return r.UseCase.MyRating(ctx, recipeID)
+}
+
+// ScaledRecipe is the resolver for the scaledRecipe field.
+func (r *queryResolver) ScaledRecipe(ctx context.Context, id string, servings int) (*entity.Recipe, error) {
+	return r.UseCase.ScaleRecipe(ctx, id, servings)
+}
+
 // GenerateRecipesFromPantry is the resolver for the generateRecipesFromPantry field.
-func (r *queryResolver) GenerateRecipesFromPantry(ctx context.Context, userID string, pantryID string) ([]*entity.Recipe, error) {
-	panic(fmt.Errorf("not implemented: GenerateRecipesFromPantry - generateRecipesFromPantry"))
+func (r *queryResolver) GenerateRecipesFromPantry(ctx context.Context, pantryID string, maxMissing *int, minCoverage *float64, useSubstitutes bool, userID *string) ([]*entity.RecipeSuggestion, error) {
+	return r.UseCase.GenerateRecipesFromPantry(ctx, pantryID, maxMissing, minCoverage, useSubstitutes)
+}
+
+// Substitutions is the resolver for the substitutions field.
+func (r *queryResolver) Substitutions(ctx context.Context, ingredient string) ([]*entity.Substitution, error) {
+	rules, err := r.UseCase.Substitutions(ctx, ingredient)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.Substitution, len(rules))
+	for i := range rules {
+		result[i] = &rules[i]
+	}
+	return result, nil
 }
 
 // GetUserPantryByID is the resolver for the getUserPantryById field.
-func (r *queryResolver) GetUserPantryByID(ctx context.Context, pantryID string) ([]*entity.PantryEntry, error) {
-	entries, err := r.UseCase.GetAllPantryEntries(ctx, pantryID)
+func (r *queryResolver) GetUserPantryByID(ctx context.Context, pantryID string, locationID *string, category *entity.Category, tags []string) ([]*entity.PantryEntry, error) {
+	var entries []entity.PantryEntry
+	var err error
+	if locationID != nil || category != nil || len(tags) > 0 {
+		filter := &entity.PantryEntryFilter{LocationID: locationID, Category: category, Tags: tags}
+		entries, err = r.UseCase.FindPantryEntries(ctx, pantryID, filter)
+	} else {
+		entries, err = r.UseCase.GetAllPantryEntries(ctx, pantryID)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +252,107 @@ func (r *queryResolver) GetUserPantryByID(ctx context.Context, pantryID string)
 	return result, nil
 }
 
+// GetUserPantryByLocation is the resolver for the getUserPantryByLocation field.
+func (r *queryResolver) GetUserPantryByLocation(ctx context.Context, pantryID string) ([]*entity.LocationGroup, error) {
+	groups, err := r.UseCase.GetPantryEntriesByLocation(ctx, pantryID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.LocationGroup, len(groups))
+	for i := range groups {
+		result[i] = &groups[i]
+	}
+	return result, nil
+}
+
+// PantryLocations is the resolver for the pantryLocations field.
+func (r *queryResolver) PantryLocations(ctx context.Context, pantryID string) ([]*entity.StorageLocation, error) {
+	locations, err := r.UseCase.GetPantryLocations(ctx, pantryID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.StorageLocation, len(locations))
+	for i := range locations {
+		result[i] = &locations[i]
+	}
+	return result, nil
+}
+
+// ExpiringEntries is the resolver for the expiringEntries field.
+func (r *queryResolver) ExpiringEntries(ctx context.Context, pantryID string, withinDays int) ([]*entity.ExpiringEntry, error) {
+	entries, err := r.UseCase.GetExpiringEntries(ctx, pantryID, withinDays)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.ExpiringEntry, len(entries))
+	for i := range entries {
+		result[i] = &entries[i]
+	}
+	return result, nil
+}
+
+// PantryMembers is the resolver for the pantryMembers field.
+func (r *queryResolver) PantryMembers(ctx context.Context, pantryID string) ([]*entity.PantryMember, error) {
+	members, err := r.UseCase.GetPantryMembers(ctx, pantryID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.PantryMember, len(members))
+	for i := range members {
+		result[i] = &members[i]
+	}
+	return result, nil
+}
+
+// ProductByBarcode is the resolver for the productByBarcode field.
+func (r *queryResolver) ProductByBarcode(ctx context.Context, code string) (*entity.Product, error) {
+	return r.UseCase.GetProductByBarcode(ctx, code)
+}
+
+// ShoppingLists is the resolver for the shoppingLists field.
+func (r *queryResolver) ShoppingLists(ctx context.Context, pantryID string) ([]*entity.ShoppingList, error) {
+	lists, err := r.UseCase.GetShoppingLists(ctx, pantryID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.ShoppingList, len(lists))
+	for i := range lists {
+		result[i] = &lists[i]
+	}
+	return result, nil
+}
+
+// ShoppingList is the resolver for the shoppingList field.
+func (r *queryResolver) ShoppingList(ctx context.Context, listID string) (*entity.ShoppingList, error) {
+	return r.UseCase.GetShoppingList(ctx, listID)
+}
+
+// MealPlans is the resolver for the mealPlans field.
+func (r *queryResolver) MealPlans(ctx context.Context, pantryID string, from time.Time, to time.Time) ([]*entity.MealPlan, error) {
+	plans, err := r.UseCase.GetMealPlans(ctx, pantryID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.MealPlan, len(plans))
+	for i := range plans {
+		result[i] = &plans[i]
+	}
+	return result, nil
+}
+
+// PantryHistory is the resolver for the pantryHistory field.
+func (r *queryResolver) PantryHistory(ctx context.Context, pantryID string, since *time.Time, entryID *string) ([]*entity.HistoryRecord, error) {
+	records, err := r.UseCase.GetPantryHistory(ctx, pantryID, since, entryID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.HistoryRecord, len(records))
+	for i := range records {
+		result[i] = &records[i]
+	}
+	return result, nil
+}
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
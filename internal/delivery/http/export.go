@@ -0,0 +1,66 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/usecase"
+)
+
+// exportPantryHandler serves GET /pantries/:id/export?format=csv|json|markdown.
+// The export is streamed straight to the response as it is written.
+func exportPantryHandler(useCase *usecase.Usecase, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, err := usecase.ParseExportFormat(c.DefaultQuery("format", "csv"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+				"code":  "INVALID_FORMAT",
+			})
+			return
+		}
+
+		export, err := useCase.ExportPantry(c.Request.Context(), c.Param("id"), format)
+		if err != nil {
+			status, code := exportErrorStatus(err)
+			if status == http.StatusInternalServerError {
+				logger.Error("Failed to export pantry",
+					zap.String("requestID", getRequestIDFromGin(c)),
+					zap.Error(err),
+				)
+			}
+			c.JSON(status, gin.H{
+				"error": err.Error(),
+				"code":  code,
+			})
+			return
+		}
+
+		c.Header("Content-Type", export.ContentType())
+		c.Header("Content-Disposition", `attachment; filename="`+export.Filename()+`"`)
+		c.Status(http.StatusOK)
+		if err := export.Write(c.Writer); err != nil {
+			// Headers are already sent, so the client sees a truncated body
+			logger.Error("Failed to stream pantry export",
+				zap.String("requestID", getRequestIDFromGin(c)),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func exportErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecase.ErrUnauthenticated):
+		return http.StatusUnauthorized, "UNAUTHORIZED"
+	case errors.Is(err, usecase.ErrForbidden):
+		return http.StatusForbidden, "FORBIDDEN"
+	case errors.Is(err, repository.ErrPantryNotFound):
+		return http.StatusNotFound, "NOT_FOUND"
+	}
+	return http.StatusInternalServerError, "INTERNAL_ERROR"
+}
@@ -94,6 +94,7 @@ func (s *Server) setupRoutes() {
 				"graphql":    "/query",
 				"playground": "/",
 				"health":     "/health",
+				"export":     "/pantries/:id/export?format=csv|json|markdown",
 			},
 		})
 	})
@@ -107,6 +108,9 @@ func (s *Server) setupRoutes() {
 	// GraphQL endpoint (GET requests for queries)
 	s.router.GET("/query", graphqlHandler(s.useCase, s.logger))
 
+	// Pantry export as CSV, JSON or Markdown
+	s.router.GET("/pantries/:id/export", exportPantryHandler(s.useCase, s.logger))
+
 	// 404 handler
 	s.router.NoRoute(func(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{
@@ -0,0 +1,69 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidFormat     = errors.New("barcode must be 8, 12, 13 or 14 digits")
+	ErrInvalidCheckDigit = errors.New("barcode check digit does not match")
+)
+
+// Normalize validates a UPC or EAN barcode and returns it in the form used as
+// the catalog key. Spaces and dashes are ignored. UPC-A codes and GTIN-14
+// codes with a leading zero are rewritten as the equivalent EAN-13, so the
+// same product scanned as UPC or EAN resolves to one key. EAN-8 codes are
+// kept as they are.
+func Normalize(code string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(code))
+
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidFormat, code)
+		}
+	}
+	switch len(digits) {
+	case 8, 12, 13, 14:
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidFormat, code)
+	}
+	if !ValidCheckDigit(digits) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCheckDigit, code)
+	}
+
+	switch {
+	case len(digits) == 12:
+		return "0" + digits, nil
+	case len(digits) == 14 && digits[0] == '0':
+		return digits[1:], nil
+	}
+	return digits, nil
+}
+
+// ValidCheckDigit reports whether the last digit of a GTIN is the correct
+// check digit for the digits before it.
+func ValidCheckDigit(digits string) bool {
+	if len(digits) < 2 {
+		return false
+	}
+	return CheckDigit(digits[:len(digits)-1]) == digits[len(digits)-1]
+}
+
+// CheckDigit computes the GTIN check digit for a code without its check
+// digit. Digits are weighted 3 and 1 alternately starting from the right.
+func CheckDigit(payload string) byte {
+	sum := 0
+	weight := 3
+	for i := len(payload) - 1; i >= 0; i-- {
+		sum += int(payload[i]-'0') * weight
+		weight = 4 - weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
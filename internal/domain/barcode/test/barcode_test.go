@@ -0,0 +1,36 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/barcode"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"4006381333931":   "4006381333931",
+		"036000291452":    "0036000291452",
+		"0-36000-29145-2": "0036000291452",
+		"00036000291452":  "0036000291452",
+		"96385074":        "96385074",
+	}
+
+	for input, expected := range tests {
+		code, err := barcode.Normalize(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, code, input)
+	}
+}
+
+func TestNormalize_Invalid(t *testing.T) {
+	_, err := barcode.Normalize("4006381333932")
+	assert.ErrorIs(t, err, barcode.ErrInvalidCheckDigit)
+
+	_, err = barcode.Normalize("12345")
+	assert.ErrorIs(t, err, barcode.ErrInvalidFormat)
+
+	_, err = barcode.Normalize("40063813339AB")
+	assert.ErrorIs(t, err, barcode.ErrInvalidFormat)
+}
@@ -0,0 +1,79 @@
+package category
+
+import (
+	"strings"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+// rules are checked in order; the first rule with a keyword matching the
+// entry name wins. Multi-word keywords come before the single words they
+// contain so that "coconut milk" is canned rather than dairy and "peanut
+// butter" is a condiment rather than dairy.
+var rules = []struct {
+	category entity.Category
+	keywords []string
+}{
+	{entity.CategoryProduce, []string{"bell pepper", "green pepper", "red pepper", "jalapeno"}},
+	{entity.CategoryCanned, []string{"coconut milk", "canned", "tinned", "beans", "chickpeas", "tomato paste", "tomato sauce", "broth", "stock"}},
+	{entity.CategoryCondiments, []string{"peanut butter", "ketchup", "mustard", "mayonnaise", "mayo", "soy sauce", "hot sauce", "vinegar", "jam", "honey", "maple syrup", "salsa", "relish"}},
+	{entity.CategoryOils, []string{"olive oil", "vegetable oil", "sesame oil", "canola oil", "coconut oil", "oil", "shortening", "lard"}},
+	{entity.CategoryBaking, []string{"baking soda", "baking powder", "brown sugar", "powdered sugar", "flour", "sugar", "yeast", "cocoa", "vanilla", "cornstarch", "chocolate chips"}},
+	{entity.CategorySpices, []string{"black pepper", "curry powder", "chili powder", "salt", "pepper", "cinnamon", "cumin", "paprika", "oregano", "basil", "thyme", "rosemary", "nutmeg", "turmeric", "ginger", "clove", "bay leaf", "spice"}},
+	{entity.CategoryDairy, []string{"milk", "buttermilk", "butter", "margarine", "cream", "cheese", "parmesan", "mozzarella", "cheddar", "yogurt", "yoghurt", "egg"}},
+	{entity.CategorySeafood, []string{"fish", "salmon", "tuna", "shrimp", "prawn", "cod", "anchovies", "anchovy", "crab", "lobster", "mussel", "clam"}},
+	{entity.CategoryMeat, []string{"chicken", "beef", "pork", "bacon", "ham", "lamb", "turkey", "sausage", "mince", "steak"}},
+	{entity.CategoryFrozen, []string{"frozen", "ice cream", "peas"}},
+	{entity.CategoryBakery, []string{"bread", "bagel", "bun", "roll", "croutons", "tortilla", "pita", "croissant"}},
+	{entity.CategoryGrains, []string{"rice", "pasta", "spaghetti", "noodle", "oats", "oatmeal", "quinoa", "barley", "couscous", "cereal", "cornmeal"}},
+	{entity.CategoryBeverages, []string{"coffee", "tea", "juice", "soda", "water", "wine", "beer"}},
+	{entity.CategorySnacks, []string{"chips", "crackers", "cookies", "popcorn", "pretzels", "nuts", "almonds", "walnuts"}},
+	{entity.CategoryProduce, []string{"romaine lettuce", "lettuce", "apple", "banana", "lemon", "lime", "orange", "onion", "garlic", "potato", "carrot", "tomato", "celery", "spinach", "cucumber", "avocado", "berries", "strawberries", "grapes", "mushroom", "herb", "parsley", "cilantro"}},
+}
+
+// Infer guesses the category of an ingredient from its name, falling back to
+// CategoryOther when no keyword matches.
+func Infer(name string) entity.Category {
+	normalized := " " + normalize(name) + " "
+	for _, rule := range rules {
+		for _, keyword := range rule.keywords {
+			if containsWord(normalized, keyword) {
+				return rule.category
+			}
+		}
+	}
+	return entity.CategoryOther
+}
+
+// containsWord reports whether keyword appears in the padded name as whole
+// words, allowing a trailing plural "s" or "es".
+func containsWord(padded string, keyword string) bool {
+	for _, suffix := range []string{" ", "s ", "es "} {
+		if strings.Contains(padded, " "+keyword+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer("_", " ", "-", " ", ",", " ").Replace(name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// NormalizeTags lowercases and trims tags, dropping empty values and
+// duplicates while keeping their first-seen order.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
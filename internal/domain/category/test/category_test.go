@@ -0,0 +1,37 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/category"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+func TestInfer(t *testing.T) {
+	tests := map[string]entity.Category{
+		"Milk":            entity.CategoryDairy,
+		"Eggs":            entity.CategoryDairy,
+		"Coconut Milk":    entity.CategoryCanned,
+		"Peanut Butter":   entity.CategoryCondiments,
+		"black_pepper":    entity.CategorySpices,
+		"Red Pepper":      entity.CategoryProduce,
+		"Flour":           entity.CategoryBaking,
+		"Basmati Rice":    entity.CategoryGrains,
+		"Chicken Thighs":  entity.CategoryMeat,
+		"Apples":          entity.CategoryProduce,
+		"Olive Oil":       entity.CategoryOils,
+		"Sourdough Bread": entity.CategoryBakery,
+		"Mystery Box":     entity.CategoryOther,
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, category.Infer(name), name)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"weeknight", "gluten free"}, category.NormalizeTags([]string{"Weeknight", " gluten  free ", "WEEKNIGHT", ""}))
+	assert.Empty(t, category.NormalizeTags(nil))
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// HistoryRecord is an immutable audit entry describing one change to a pantry
+// or one of its entries. Before is nil for inserts and After is nil for
+// deletions; both are nil for changes to the pantry itself.
+type HistoryRecord struct {
+	ID        string        `json:"id" bson:"id"`
+	PantryID  string        `json:"pantryId" bson:"pantryId"`
+	EntryID   *string       `json:"entryId,omitempty" bson:"entryId,omitempty"`
+	Action    HistoryAction `json:"action" bson:"action"`
+	ActorID   string        `json:"actorId" bson:"actorId"`
+	RequestID *string       `json:"requestId,omitempty" bson:"requestId,omitempty"`
+	Before    *PantryEntry  `json:"before,omitempty" bson:"before,omitempty"`
+	After     *PantryEntry  `json:"after,omitempty" bson:"after,omitempty"`
+	Timestamp time.Time     `json:"timestamp" bson:"timestamp"`
+}
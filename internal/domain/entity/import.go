@@ -0,0 +1,17 @@
+package entity
+
+// ImportRowError describes why a single row of a bulk import was rejected.
+// Rows are numbered from 1, not counting a CSV header.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// EntryImportResult reports the outcome of a bulk pantry entry import.
+// Imports are all or nothing, so Imported is zero whenever Errors is not
+// empty, and always zero for a dry run.
+type EntryImportResult struct {
+	Imported int              `json:"imported"`
+	DryRun   bool             `json:"dryRun"`
+	Errors   []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// MealPlan schedules a recipe for a meal slot on a given day in a pantry's
+// calendar. Date is always midnight UTC of the planned day.
+type MealPlan struct {
+	ID        string    `json:"id" bson:"id"`
+	PantryID  string    `json:"pantryId" bson:"pantryId"`
+	Date      time.Time `json:"date" bson:"date"`
+	Slot      MealSlot  `json:"slot" bson:"slot"`
+	RecipeID  string    `json:"recipeId" bson:"recipeId"`
+	Servings  *int      `json:"servings,omitempty" bson:"servings,omitempty"`
+	Note      *string   `json:"note,omitempty" bson:"note,omitempty"`
+	CreatedBy string    `json:"createdBy" bson:"createdBy"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+
+	// Recipe and MissingIngredients are filled in when plans are loaded, so
+	// a calendar query resolves them without a lookup per plan. They are
+	// not stored.
+	Recipe             *Recipe  `json:"recipe,omitempty" bson:"-"`
+	MissingIngredients []string `json:"missingIngredients,omitempty" bson:"-"`
+}
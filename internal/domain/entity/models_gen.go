@@ -2,18 +2,179 @@
 
 package entity
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type AddToPantryInput struct {
+	LocationID *string    `json:"locationID,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+}
+
+type AppliedSubstitution struct {
+	// Name of the recipe ingredient that was replaced.
+	Ingredient string        `json:"ingredient"`
+	Rule       *Substitution `json:"rule"`
+	// The replacement for the amount the recipe calls for, or the rule's own amounts when the units do not convert.
+	Replacement []*SubstitutionPart `json:"replacement"`
+}
+
+type CheckShoppingItemResult struct {
+	Item  *ShoppingListItem `json:"item"`
+	Entry *PantryEntry      `json:"entry,omitempty"`
+}
+
+type ConsumeResult struct {
+	EntryID      string  `json:"entryID"`
+	Consumed     float64 `json:"consumed"`
+	QuantityType *string `json:"quantityType,omitempty"`
+	Depleted     bool    `json:"depleted"`
+}
+
+type CookReport struct {
+	Recipe      *Recipe             `json:"recipe"`
+	Ingredients []*CookedIngredient `json:"ingredients"`
+	// Whether every required ingredient was fully on hand.
+	Complete bool `json:"complete"`
+}
+
+type CookedIngredient struct {
+	Name         string `json:"name"`
+	IngredientID string `json:"ingredientId"`
+	// Amount the recipe calls for in its own unit, or null when it gives none.
+	Required *float64      `json:"required,omitempty"`
+	Unit     *string       `json:"unit,omitempty"`
+	Optional bool          `json:"optional"`
+	Used     []*EntryUsage `json:"used"`
+	// Amount, in the recipe's unit, that the pantry could not supply.
+	Short float64 `json:"short"`
+}
+
+type EntryUsage struct {
+	EntryID string `json:"entryID"`
+	Name    string `json:"name"`
+	// Amount taken, in the entry's own unit.
+	Amount       float64 `json:"amount"`
+	QuantityType *string `json:"quantityType,omitempty"`
+	Depleted     bool    `json:"depleted"`
+}
+
+type ExpiringEntry struct {
+	Entry               *PantryEntry `json:"entry"`
+	DaysUntilExpiration int          `json:"daysUntilExpiration"`
+	Expired             bool         `json:"expired"`
+}
+
+type LocationGroup struct {
+	Location *StorageLocation `json:"location,omitempty"`
+	Entries  []*PantryEntry   `json:"entries"`
+}
+
+type MealPlanInput struct {
+	Date     time.Time `json:"date"`
+	Slot     MealSlot  `json:"slot"`
+	RecipeID string    `json:"recipeId"`
+	Servings *int      `json:"servings,omitempty"`
+	Note     *string   `json:"note,omitempty"`
+}
+
+type MergeReport struct {
+	Groups  []*MergedEntryGroup `json:"groups"`
+	Removed int                 `json:"removed"`
+}
+
+type MergedEntryGroup struct {
+	Entry          *PantryEntry `json:"entry"`
+	MergedEntryIDs []string     `json:"mergedEntryIDs"`
+}
+
 type Mutation struct {
 }
 
 type PantryEntryInput struct {
-	Name         string   `json:"name"`
-	Quantity     *float64 `json:"quantity,omitempty"`
-	QuantityType *string  `json:"quantityType,omitempty"`
+	Name         string     `json:"name"`
+	Quantity     *float64   `json:"quantity,omitempty"`
+	QuantityType *string    `json:"quantityType,omitempty"`
+	Expiration   *time.Time `json:"expiration,omitempty"`
+	LocationID   *string    `json:"locationID,omitempty"`
+	Category     *Category  `json:"category,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
 }
 
 type Query struct {
 }
 
+type RecipeImportError struct {
+	// Position of the recipe in the file, starting at 1.
+	Index   int     `json:"index"`
+	Name    *string `json:"name,omitempty"`
+	Message string  `json:"message"`
+}
+
+type RecipeImportResult struct {
+	Imported int                  `json:"imported"`
+	DryRun   bool                 `json:"dryRun"`
+	Recipes  []*Recipe            `json:"recipes"`
+	Errors   []*RecipeImportError `json:"errors"`
+}
+
+type RecipeIngredientInput struct {
+	Name        string   `json:"name"`
+	Quantity    *float64 `json:"quantity,omitempty"`
+	Unit        *string  `json:"unit,omitempty"`
+	Optional    bool     `json:"optional"`
+	Preparation *string  `json:"preparation,omitempty"`
+}
+
+type RecipeInput struct {
+	Name        string                   `json:"name"`
+	Description *string                  `json:"description,omitempty"`
+	Cuisine     *string                  `json:"cuisine,omitempty"`
+	Difficulty  *int                     `json:"difficulty,omitempty"`
+	SourceURL   *string                  `json:"sourceUrl,omitempty"`
+	Servings    *int                     `json:"servings,omitempty"`
+	PrepTime    *int                     `json:"prepTime,omitempty"`
+	CookTime    *int                     `json:"cookTime,omitempty"`
+	Ingredients []*RecipeIngredientInput `json:"ingredients"`
+	Steps       []*RecipeStepInput       `json:"steps,omitempty"`
+}
+
+type RecipeSearchPage struct {
+	Hits        []*RecipeSearchHit `json:"hits"`
+	EndCursor   *string            `json:"endCursor,omitempty"`
+	HasNextPage bool               `json:"hasNextPage"`
+}
+
+type RecipeStepInput struct {
+	Text            string `json:"text"`
+	DurationMinutes *int   `json:"durationMinutes,omitempty"`
+	// Ingredient names or IDs, each of which must be in the recipe.
+	Ingredients []string `json:"ingredients,omitempty"`
+	Passive     bool     `json:"passive"`
+}
+
+type RecipeSuggestion struct {
+	Recipe             *Recipe                `json:"recipe"`
+	Coverage           float64                `json:"coverage"`
+	MissingIngredients []string               `json:"missingIngredients"`
+	UsedEntries        []*PantryEntry         `json:"usedEntries"`
+	Substitutions      []*AppliedSubstitution `json:"substitutions"`
+}
+
+type ShoppingListItemInput struct {
+	Name     string   `json:"name"`
+	Quantity *float64 `json:"quantity,omitempty"`
+	Unit     *string  `json:"unit,omitempty"`
+}
+
+type StorageLocationInput struct {
+	Name string       `json:"name"`
+	Kind LocationKind `json:"kind"`
+}
+
 type UserRegisterInput struct {
 	Name      *string `json:"name,omitempty"`
 	Email     string  `json:"email"`
@@ -21,3 +182,338 @@ type UserRegisterInput struct {
 	LastName  *string `json:"lastName,omitempty"`
 	Password  string  `json:"password"`
 }
+
+type Category string
+
+const (
+	CategoryDairy      Category = "DAIRY"
+	CategoryProduce    Category = "PRODUCE"
+	CategoryMeat       Category = "MEAT"
+	CategorySeafood    Category = "SEAFOOD"
+	CategoryGrains     Category = "GRAINS"
+	CategoryBakery     Category = "BAKERY"
+	CategoryBaking     Category = "BAKING"
+	CategorySpices     Category = "SPICES"
+	CategoryCondiments Category = "CONDIMENTS"
+	CategoryOils       Category = "OILS"
+	CategoryCanned     Category = "CANNED"
+	CategoryFrozen     Category = "FROZEN"
+	CategoryBeverages  Category = "BEVERAGES"
+	CategorySnacks     Category = "SNACKS"
+	CategoryOther      Category = "OTHER"
+)
+
+var AllCategory = []Category{
+	CategoryDairy,
+	CategoryProduce,
+	CategoryMeat,
+	CategorySeafood,
+	CategoryGrains,
+	CategoryBakery,
+	CategoryBaking,
+	CategorySpices,
+	CategoryCondiments,
+	CategoryOils,
+	CategoryCanned,
+	CategoryFrozen,
+	CategoryBeverages,
+	CategorySnacks,
+	CategoryOther,
+}
+
+func (e Category) IsValid() bool {
+	switch e {
+	case CategoryDairy, CategoryProduce, CategoryMeat, CategorySeafood, CategoryGrains, CategoryBakery, CategoryBaking, CategorySpices, CategoryCondiments, CategoryOils, CategoryCanned, CategoryFrozen, CategoryBeverages, CategorySnacks, CategoryOther:
+		return true
+	}
+	return false
+}
+
+func (e Category) String() string {
+	return string(e)
+}
+
+func (e *Category) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Category(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Category", str)
+	}
+	return nil
+}
+
+func (e Category) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type HistoryAction string
+
+const (
+	HistoryActionEntryInserted HistoryAction = "ENTRY_INSERTED"
+	HistoryActionEntryUpdated  HistoryAction = "ENTRY_UPDATED"
+	HistoryActionEntryConsumed HistoryAction = "ENTRY_CONSUMED"
+	HistoryActionEntryDeleted  HistoryAction = "ENTRY_DELETED"
+	HistoryActionPantryCreated HistoryAction = "PANTRY_CREATED"
+	HistoryActionPantryDeleted HistoryAction = "PANTRY_DELETED"
+)
+
+var AllHistoryAction = []HistoryAction{
+	HistoryActionEntryInserted,
+	HistoryActionEntryUpdated,
+	HistoryActionEntryConsumed,
+	HistoryActionEntryDeleted,
+	HistoryActionPantryCreated,
+	HistoryActionPantryDeleted,
+}
+
+func (e HistoryAction) IsValid() bool {
+	switch e {
+	case HistoryActionEntryInserted, HistoryActionEntryUpdated, HistoryActionEntryConsumed, HistoryActionEntryDeleted, HistoryActionPantryCreated, HistoryActionPantryDeleted:
+		return true
+	}
+	return false
+}
+
+func (e HistoryAction) String() string {
+	return string(e)
+}
+
+func (e *HistoryAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HistoryAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HistoryAction", str)
+	}
+	return nil
+}
+
+func (e HistoryAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV  ImportFormat = "CSV"
+	ImportFormatJSON ImportFormat = "JSON"
+)
+
+var AllImportFormat = []ImportFormat{
+	ImportFormatCSV,
+	ImportFormatJSON,
+}
+
+func (e ImportFormat) IsValid() bool {
+	switch e {
+	case ImportFormatCSV, ImportFormatJSON:
+		return true
+	}
+	return false
+}
+
+func (e ImportFormat) String() string {
+	return string(e)
+}
+
+func (e *ImportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportFormat", str)
+	}
+	return nil
+}
+
+func (e ImportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type LocationKind string
+
+const (
+	LocationKindShelf   LocationKind = "SHELF"
+	LocationKindFridge  LocationKind = "FRIDGE"
+	LocationKindFreezer LocationKind = "FREEZER"
+	LocationKindCellar  LocationKind = "CELLAR"
+	LocationKindOther   LocationKind = "OTHER"
+)
+
+var AllLocationKind = []LocationKind{
+	LocationKindShelf,
+	LocationKindFridge,
+	LocationKindFreezer,
+	LocationKindCellar,
+	LocationKindOther,
+}
+
+func (e LocationKind) IsValid() bool {
+	switch e {
+	case LocationKindShelf, LocationKindFridge, LocationKindFreezer, LocationKindCellar, LocationKindOther:
+		return true
+	}
+	return false
+}
+
+func (e LocationKind) String() string {
+	return string(e)
+}
+
+func (e *LocationKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LocationKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LocationKind", str)
+	}
+	return nil
+}
+
+func (e LocationKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MealSlot string
+
+const (
+	MealSlotBreakfast MealSlot = "BREAKFAST"
+	MealSlotLunch     MealSlot = "LUNCH"
+	MealSlotDinner    MealSlot = "DINNER"
+	MealSlotSnack     MealSlot = "SNACK"
+)
+
+var AllMealSlot = []MealSlot{
+	MealSlotBreakfast,
+	MealSlotLunch,
+	MealSlotDinner,
+	MealSlotSnack,
+}
+
+func (e MealSlot) IsValid() bool {
+	switch e {
+	case MealSlotBreakfast, MealSlotLunch, MealSlotDinner, MealSlotSnack:
+		return true
+	}
+	return false
+}
+
+func (e MealSlot) String() string {
+	return string(e)
+}
+
+func (e *MealSlot) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MealSlot(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MealSlot", str)
+	}
+	return nil
+}
+
+func (e MealSlot) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type PantryRole string
+
+const (
+	PantryRoleOwner  PantryRole = "OWNER"
+	PantryRoleEditor PantryRole = "EDITOR"
+	PantryRoleViewer PantryRole = "VIEWER"
+)
+
+var AllPantryRole = []PantryRole{
+	PantryRoleOwner,
+	PantryRoleEditor,
+	PantryRoleViewer,
+}
+
+func (e PantryRole) IsValid() bool {
+	switch e {
+	case PantryRoleOwner, PantryRoleEditor, PantryRoleViewer:
+		return true
+	}
+	return false
+}
+
+func (e PantryRole) String() string {
+	return string(e)
+}
+
+func (e *PantryRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PantryRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PantryRole", str)
+	}
+	return nil
+}
+
+func (e PantryRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type RecipeImportFormat string
+
+const (
+	RecipeImportFormatJSONLd     RecipeImportFormat = "JSONLD"
+	RecipeImportFormatMarkdown   RecipeImportFormat = "MARKDOWN"
+	RecipeImportFormatMealmaster RecipeImportFormat = "MEALMASTER"
+)
+
+var AllRecipeImportFormat = []RecipeImportFormat{
+	RecipeImportFormatJSONLd,
+	RecipeImportFormatMarkdown,
+	RecipeImportFormatMealmaster,
+}
+
+func (e RecipeImportFormat) IsValid() bool {
+	switch e {
+	case RecipeImportFormatJSONLd, RecipeImportFormatMarkdown, RecipeImportFormatMealmaster:
+		return true
+	}
+	return false
+}
+
+func (e RecipeImportFormat) String() string {
+	return string(e)
+}
+
+func (e *RecipeImportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RecipeImportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RecipeImportFormat", str)
+	}
+	return nil
+}
+
+func (e RecipeImportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -5,17 +5,125 @@ import (
 )
 
 type Pantry struct {
-	ID        string         `json:"id" bson:"id"`
-	Name      string         `json:"name" bson:"name"`
-	OwnerID   string         `json:"ownerId" bson:"ownerId"`
-	CreatedAt time.Time      `json:"createdAt" bson:"createdAt"`
-	Entries   *[]PantryEntry `json:"pantry_entries" bson:"pantry_entries"`
+	ID        string            `json:"id" bson:"id"`
+	Name      string            `json:"name" bson:"name"`
+	OwnerID   string            `json:"ownerId" bson:"ownerId"`
+	Members   []PantryMember    `json:"members" bson:"members"`
+	Locations []StorageLocation `json:"locations" bson:"locations"`
+	CreatedAt time.Time         `json:"createdAt" bson:"createdAt"`
+	Entries   *[]PantryEntry    `json:"pantry_entries" bson:"pantry_entries"`
 }
 
 type PantryEntry struct {
 	ID           string     `json:"id" bson:"id"`
 	Name         string     `json:"name" bson:"name"`
+	IngredientID string     `json:"ingredientId,omitempty" bson:"ingredientId,omitempty"`
 	Expiration   *time.Time `json:"expiration,omitempty" bson:"expiration,omitempty"`
 	Quantity     *float64   `json:"quantity,omitempty" bson:"quantity,omitempty"`
 	QuantityType *string    `json:"quantityType,omitempty" bson:"quantityType,omitempty"`
+	LocationID   *string    `json:"locationID,omitempty" bson:"locationId,omitempty"`
+	Category     *Category  `json:"category,omitempty" bson:"category,omitempty"`
+	Tags         []string   `json:"tags,omitempty" bson:"tags,omitempty"`
+}
+
+// PantryEntryPatch carries the fields of an entry to change. IngredientID is
+// not accepted from clients; it is derived from Name when the name changes.
+type PantryEntryPatch struct {
+	Name         *string    `json:"name,omitempty"`
+	Quantity     *float64   `json:"quantity,omitempty"`
+	QuantityType *string    `json:"quantityType,omitempty"`
+	Expiration   *time.Time `json:"expiration,omitempty"`
+	Category     *Category  `json:"category,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+	IngredientID *string    `json:"-"`
+}
+
+// PantryEntryFilter narrows a pantry's entries. Unset fields match every
+// entry; an entry must carry all of Tags to match.
+type PantryEntryFilter struct {
+	LocationID *string
+	Category   *Category
+	Tags       []string
+}
+
+type StorageLocation struct {
+	ID   string       `json:"id" bson:"id"`
+	Name string       `json:"name" bson:"name"`
+	Kind LocationKind `json:"kind" bson:"kind"`
+}
+
+type PantryMember struct {
+	UserID  string     `json:"userID" bson:"userId"`
+	Role    PantryRole `json:"role" bson:"role"`
+	AddedAt time.Time  `json:"addedAt" bson:"addedAt"`
+}
+
+type PantryInvite struct {
+	Code       string     `json:"code" bson:"code"`
+	PantryID   string     `json:"pantryID" bson:"pantryId"`
+	Email      string     `json:"email" bson:"email"`
+	Role       PantryRole `json:"role" bson:"role"`
+	InvitedBy  string     `json:"invitedBy" bson:"invitedBy"`
+	CreatedAt  time.Time  `json:"createdAt" bson:"createdAt"`
+	ExpiresAt  time.Time  `json:"expiresAt" bson:"expiresAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty" bson:"acceptedAt,omitempty"`
+	AcceptedBy *string    `json:"acceptedBy,omitempty" bson:"acceptedBy,omitempty"`
+}
+
+// Location returns the storage location with the given ID, or nil if the
+// pantry does not define it.
+func (p *Pantry) Location(locationID string) *StorageLocation {
+	for i := range p.Locations {
+		if p.Locations[i].ID == locationID {
+			return &p.Locations[i]
+		}
+	}
+	return nil
+}
+
+// Entry returns the entry with the given ID, or nil if the pantry was loaded
+// without it.
+func (p *Pantry) Entry(entryID string) *PantryEntry {
+	if p.Entries == nil {
+		return nil
+	}
+	for i := range *p.Entries {
+		if (*p.Entries)[i].ID == entryID {
+			return &(*p.Entries)[i]
+		}
+	}
+	return nil
+}
+
+// EntryList returns the pantry's entries, or an empty slice when it has none.
+func (p *Pantry) EntryList() []PantryEntry {
+	if p.Entries == nil {
+		return []PantryEntry{}
+	}
+	return *p.Entries
+}
+
+// RoleOf returns the role a user holds in the pantry. Pantries created before
+// membership existed have no members, so their OwnerID is treated as owner.
+func (p *Pantry) RoleOf(userID string) (PantryRole, bool) {
+	for _, member := range p.Members {
+		if member.UserID == userID {
+			return member.Role, true
+		}
+	}
+	if userID != "" && p.OwnerID == userID {
+		return PantryRoleOwner, true
+	}
+	return "", false
+}
+
+// Allows reports whether the role grants at least the access of required.
+func (r PantryRole) Allows(required PantryRole) bool {
+	return roleRank[r] >= roleRank[required]
+}
+
+var roleRank = map[PantryRole]int{
+	PantryRoleViewer: 1,
+	PantryRoleEditor: 2,
+	PantryRoleOwner:  3,
 }
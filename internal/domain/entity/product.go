@@ -0,0 +1,17 @@
+package entity
+
+// Product is an entry in the local product catalog, keyed by its normalized
+// UPC/EAN barcode.
+type Product struct {
+	Code        string    `json:"code" bson:"code"`
+	Name        string    `json:"name" bson:"name"`
+	Brand       *string   `json:"brand,omitempty" bson:"brand,omitempty"`
+	DefaultUnit *string   `json:"defaultUnit,omitempty" bson:"defaultUnit,omitempty"`
+	PackageSize *float64  `json:"packageSize,omitempty" bson:"packageSize,omitempty"`
+	Category    *Category `json:"category,omitempty" bson:"category,omitempty"`
+}
+
+type ProductImportResult struct {
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors"`
+}
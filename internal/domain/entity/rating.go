@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// RecipeRating is one user's rating of a recipe. Each user has at most one
+// rating per recipe; rating again replaces it.
+type RecipeRating struct {
+	RecipeID  string    `json:"recipeId" bson:"recipeId"`
+	UserID    string    `json:"userId" bson:"userId"`
+	Stars     int       `json:"stars" bson:"stars"`
+	Note      *string   `json:"note,omitempty" bson:"note,omitempty"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
@@ -1,12 +1,109 @@
 package entity
 
+import "math"
+
 type Recipe struct {
-	ID          string                 `json:"id" bson:"id"`
-	Name        string                 `json:"name" bson:"name"`
-	Rating      *int                   `json:"rating,omitempty" bson:"rating,omitempty"`
-	Ingredients map[string]interface{} `json:"ingredients" bson:"ingredients"`
-	Difficulty  *int                   `json:"difficulty,omitempty" bson:"difficulty,omitempty"`
-	Cuisine     *string                `json:"cuisine,omitempty" bson:"cuisine,omitempty"`
-	Description string                 `json:"description" bson:"description"`
-	SourceUrl   *string                `json:"source_url,omitempty" bson:"source_url,omitempty"`
+	ID          string             `json:"id" bson:"id"`
+	Name        string             `json:"name" bson:"name"`
+	Ingredients []RecipeIngredient `json:"ingredients" bson:"ingredients"`
+	Difficulty  *int               `json:"difficulty,omitempty" bson:"difficulty,omitempty"`
+	Cuisine     *string            `json:"cuisine,omitempty" bson:"cuisine,omitempty"`
+	Description string             `json:"description" bson:"description"`
+	SourceUrl   *string            `json:"source_url,omitempty" bson:"source_url,omitempty"`
+	Servings    *int               `json:"servings,omitempty" bson:"servings,omitempty"`
+	PrepTime    *int               `json:"prep_time,omitempty" bson:"prep_time,omitempty"`
+	CookTime    *int               `json:"cook_time,omitempty" bson:"cook_time,omitempty"`
+	Steps       []RecipeStep       `json:"steps,omitempty" bson:"steps,omitempty"`
+	CreatedBy   string             `json:"createdBy,omitempty" bson:"createdBy,omitempty"`
+
+	// Rating aggregates, maintained as users rate the recipe. The total is
+	// kept so the average can be recomputed exactly after each change.
+	RatingAverage float64 `json:"ratingAverage" bson:"ratingAverage"`
+	RatingCount   int     `json:"ratingCount" bson:"ratingCount"`
+	RatingTotal   int     `json:"-" bson:"ratingTotal"`
+}
+
+// RecipeStep is one instruction of a recipe's method, in order. Ingredients
+// are referenced by canonical ingredient ID. Passive steps, such as resting
+// or baking, take time without needing the cook's attention.
+type RecipeStep struct {
+	Text            string   `json:"text" bson:"text"`
+	DurationMinutes *int     `json:"durationMinutes,omitempty" bson:"durationMinutes,omitempty"`
+	IngredientIDs   []string `json:"ingredientIds,omitempty" bson:"ingredientIds,omitempty"`
+	Passive         bool     `json:"passive" bson:"passive"`
+}
+
+// TotalTime is the sum of the step durations in minutes. Recipes whose steps
+// carry no durations fall back to their preparation plus cooking time; nil
+// means the time is unknown.
+func (r *Recipe) TotalTime() *int {
+	if total, ok := r.stepMinutes(false); ok {
+		return &total
+	}
+	if r.PrepTime == nil && r.CookTime == nil {
+		return nil
+	}
+	total := 0
+	if r.PrepTime != nil {
+		total += *r.PrepTime
+	}
+	if r.CookTime != nil {
+		total += *r.CookTime
+	}
+	return &total
+}
+
+// ActiveTime is the time in minutes spent on steps that are not passive, or
+// nil when no step carries a duration.
+func (r *Recipe) ActiveTime() *int {
+	if active, ok := r.stepMinutes(true); ok {
+		return &active
+	}
+	return nil
+}
+
+// stepMinutes sums step durations, optionally only those of active steps.
+// It reports false when no step has a duration at all.
+func (r *Recipe) stepMinutes(activeOnly bool) (int, bool) {
+	total, timed := 0, false
+	for _, step := range r.Steps {
+		if step.DurationMinutes == nil {
+			continue
+		}
+		timed = true
+		if !activeOnly || !step.Passive {
+			total += *step.DurationMinutes
+		}
+	}
+	return total, timed
+}
+
+// Rating is the average rating rounded to whole stars, or nil for a recipe
+// nobody has rated.
+func (r *Recipe) Rating() *int {
+	if r.RatingCount == 0 {
+		return nil
+	}
+	stars := int(math.Round(r.RatingAverage))
+	return &stars
+}
+
+// RecipeIngredient is one line of a recipe's ingredient list. A quantity
+// without a unit is a count of pieces; neither is set for ingredients added
+// to taste. IngredientID is the canonical ingredient, filled in when the
+// recipe is written.
+type RecipeIngredient struct {
+	Name         string   `json:"name" bson:"name"`
+	IngredientID string   `json:"ingredientId,omitempty" bson:"ingredientId,omitempty"`
+	Quantity     *float64 `json:"quantity,omitempty" bson:"quantity,omitempty"`
+	Unit         *string  `json:"unit,omitempty" bson:"unit,omitempty"`
+	Optional     bool     `json:"optional" bson:"optional"`
+	Preparation  *string  `json:"preparation,omitempty" bson:"preparation,omitempty"`
+}
+
+// RecipeSearchHit is a recipe matched by a text search together with its
+// relevance score.
+type RecipeSearchHit struct {
+	Recipe Recipe  `json:"recipe" bson:",inline"`
+	Score  float64 `json:"score" bson:"score"`
 }
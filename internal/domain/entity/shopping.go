@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// ShoppingList is a list of things to buy for a pantry. A pantry can keep
+// several lists, e.g. one per store.
+type ShoppingList struct {
+	ID        string             `json:"id" bson:"id"`
+	PantryID  string             `json:"pantryId" bson:"pantryId"`
+	Name      string             `json:"name" bson:"name"`
+	Items     []ShoppingListItem `json:"items" bson:"items"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+type ShoppingListItem struct {
+	ID        string     `json:"id" bson:"id"`
+	Name      string     `json:"name" bson:"name"`
+	Quantity  *float64   `json:"quantity,omitempty" bson:"quantity,omitempty"`
+	Unit      *string    `json:"unit,omitempty" bson:"unit,omitempty"`
+	Checked   bool       `json:"checked" bson:"checked"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty" bson:"checkedAt,omitempty"`
+	AddedBy   string     `json:"addedBy" bson:"addedBy"`
+}
+
+// Item returns the item with the given ID, or nil when the list has none.
+func (l *ShoppingList) Item(id string) *ShoppingListItem {
+	for i := range l.Items {
+		if l.Items[i].ID == id {
+			return &l.Items[i]
+		}
+	}
+	return nil
+}
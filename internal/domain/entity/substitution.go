@@ -0,0 +1,23 @@
+package entity
+
+// Substitution is a rule that an ingredient can be replaced by one or more
+// others. The amounts give the ratio: Quantity of the ingredient in Unit is
+// replaced by the listed amount of every part, so buttermilk might be
+// 1 cup = 1 cup milk + 1 tbsp lemon juice. Note says when the rule holds.
+type Substitution struct {
+	ID           string             `json:"id" bson:"id"`
+	Name         string             `json:"name" bson:"name"`
+	IngredientID string             `json:"ingredientId" bson:"ingredientId"`
+	Quantity     float64            `json:"quantity" bson:"quantity"`
+	Unit         *string            `json:"unit,omitempty" bson:"unit,omitempty"`
+	Replacement  []SubstitutionPart `json:"replacement" bson:"replacement"`
+	Note         *string            `json:"note,omitempty" bson:"note,omitempty"`
+}
+
+// SubstitutionPart is one ingredient of a substitution's replacement.
+type SubstitutionPart struct {
+	Name         string  `json:"name" bson:"name"`
+	IngredientID string  `json:"ingredientId" bson:"ingredientId"`
+	Quantity     float64 `json:"quantity" bson:"quantity"`
+	Unit         *string `json:"unit,omitempty" bson:"unit,omitempty"`
+}
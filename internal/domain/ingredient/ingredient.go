@@ -0,0 +1,144 @@
+package ingredient
+
+import "strings"
+
+// Ingredient is a canonical ingredient. IDs are lower snake case and singular,
+// e.g. "black_pepper".
+type Ingredient struct {
+	ID      string
+	Name    string
+	Aliases []string
+}
+
+// catalog lists the known ingredients. The ID and name of each are matched
+// automatically, so aliases only need to cover other spellings and synonyms.
+// Plurals are handled by Resolve and need not be listed.
+var catalog = []Ingredient{
+	// Dairy and eggs
+	{ID: "milk", Name: "Milk", Aliases: []string{"whole milk", "skim milk", "semi skimmed milk"}},
+	{ID: "butter", Name: "Butter", Aliases: []string{"unsalted butter", "salted butter"}},
+	{ID: "cream", Name: "Cream", Aliases: []string{"heavy cream", "double cream", "whipping cream"}},
+	{ID: "cheese", Name: "Cheese"},
+	{ID: "parmesan", Name: "Parmesan", Aliases: []string{"parmesan cheese", "parmigiano reggiano", "parmigiano"}},
+	{ID: "yogurt", Name: "Yogurt", Aliases: []string{"yoghurt", "plain yogurt"}},
+	{ID: "egg", Name: "Egg", Aliases: []string{"large egg", "whole egg"}},
+
+	// Meat and fish
+	{ID: "chicken", Name: "Chicken", Aliases: []string{"whole chicken"}},
+	{ID: "chicken_breast", Name: "Chicken breast", Aliases: []string{"chicken breast fillet"}},
+	{ID: "beef", Name: "Beef"},
+	{ID: "ground_beef", Name: "Ground beef", Aliases: []string{"minced beef", "beef mince"}},
+	{ID: "bacon", Name: "Bacon", Aliases: []string{"bacon rasher", "streaky bacon"}},
+	{ID: "anchovy", Name: "Anchovy", Aliases: []string{"anchovy fillet"}},
+
+	// Produce
+	{ID: "apple", Name: "Apple"},
+	{ID: "banana", Name: "Banana"},
+	{ID: "lemon", Name: "Lemon"},
+	{ID: "lime", Name: "Lime"},
+	{ID: "onion", Name: "Onion", Aliases: []string{"yellow onion", "white onion", "brown onion"}},
+	{ID: "red_onion", Name: "Red onion"},
+	{ID: "garlic", Name: "Garlic", Aliases: []string{"garlic clove", "clove of garlic"}},
+	{ID: "ginger", Name: "Ginger", Aliases: []string{"fresh ginger", "ginger root"}},
+	{ID: "potato", Name: "Potato"},
+	{ID: "tomato", Name: "Tomato"},
+	{ID: "carrot", Name: "Carrot"},
+	{ID: "romaine_lettuce", Name: "Romaine lettuce", Aliases: []string{"romaine", "cos lettuce"}},
+	{ID: "spinach", Name: "Spinach", Aliases: []string{"baby spinach"}},
+	{ID: "cilantro", Name: "Cilantro", Aliases: []string{"coriander leaves", "fresh coriander"}},
+
+	// Bakery and dry goods
+	{ID: "bread", Name: "Bread"},
+	{ID: "crouton", Name: "Crouton"},
+	{ID: "flour", Name: "Flour", Aliases: []string{"all purpose flour", "plain flour", "ap flour"}},
+	{ID: "sugar", Name: "Sugar", Aliases: []string{"white sugar", "granulated sugar", "caster sugar"}},
+	{ID: "brown_sugar", Name: "Brown sugar"},
+	{ID: "rice", Name: "Rice", Aliases: []string{"white rice", "long grain rice"}},
+	{ID: "pasta", Name: "Pasta"},
+	{ID: "oats", Name: "Oats", Aliases: []string{"oat", "rolled oats", "oatmeal"}},
+	{ID: "baking_powder", Name: "Baking powder"},
+	{ID: "baking_soda", Name: "Baking soda", Aliases: []string{"bicarbonate of soda", "bicarb"}},
+
+	// Spices and condiments
+	{ID: "salt", Name: "Salt", Aliases: []string{"table salt", "sea salt", "kosher salt"}},
+	{ID: "black_pepper", Name: "Black pepper", Aliases: []string{"pepper", "ground black pepper", "ground pepper"}},
+	{ID: "cinnamon", Name: "Cinnamon", Aliases: []string{"ground cinnamon"}},
+	{ID: "curry_powder", Name: "Curry powder"},
+	{ID: "soy_sauce", Name: "Soy sauce", Aliases: []string{"soya sauce"}},
+
+	// Oils and canned goods
+	{ID: "olive_oil", Name: "Olive oil", Aliases: []string{"extra virgin olive oil", "evoo"}},
+	{ID: "vegetable_oil", Name: "Vegetable oil", Aliases: []string{"canola oil", "rapeseed oil"}},
+	{ID: "coconut_milk", Name: "Coconut milk", Aliases: []string{"canned coconut milk"}},
+}
+
+// index maps every normalized, singular spelling to its canonical ID.
+var index = buildIndex()
+
+func buildIndex() map[string]string {
+	index := make(map[string]string)
+	for _, ingredient := range catalog {
+		names := append([]string{ingredient.ID, ingredient.Name}, ingredient.Aliases...)
+		for _, name := range names {
+			index[singular(Normalize(name))] = ingredient.ID
+		}
+	}
+	return index
+}
+
+// Normalize lower-cases a name, treats underscores and hyphens as spaces and
+// collapses whitespace, so "Black_Pepper" becomes "black pepper".
+func Normalize(name string) string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer("_", " ", "-", " ").Replace(name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Resolve returns the canonical ID for an ingredient name. Names outside the
+// catalog still get a stable ID derived from their normalized singular form,
+// so "Sumac" and "sumac" resolve alike even though neither is listed.
+func Resolve(name string) string {
+	key := singular(Normalize(name))
+	if id, ok := index[key]; ok {
+		return id
+	}
+	return strings.ReplaceAll(key, " ", "_")
+}
+
+// Lookup returns the catalog ingredient with the given canonical ID.
+func Lookup(id string) (Ingredient, bool) {
+	for _, ingredient := range catalog {
+		if ingredient.ID == id {
+			return ingredient, true
+		}
+	}
+	return Ingredient{}, false
+}
+
+// singular turns the last word of a normalized name into its singular form.
+func singular(name string) string {
+	i := strings.LastIndex(name, " ")
+	return name[:i+1] + Singular(name[i+1:])
+}
+
+// Singular folds a lowercase word to its singular form using common English
+// plural endings, so "tomatoes" becomes "tomato".
+func Singular(word string) string {
+	switch {
+	case len(word) <= 3:
+	case strings.HasSuffix(word, "ies"):
+		word = strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"),
+		strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "shes"),
+		strings.HasSuffix(word, "sses"),
+		strings.HasSuffix(word, "xes"):
+		word = strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"),
+		strings.HasSuffix(word, "us"),
+		strings.HasSuffix(word, "is"):
+	case strings.HasSuffix(word, "s"):
+		word = strings.TrimSuffix(word, "s")
+	}
+	return word
+}
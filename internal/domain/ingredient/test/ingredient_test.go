@@ -0,0 +1,60 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/ingredient"
+)
+
+func TestResolve(t *testing.T) {
+	tests := map[string]string{
+		"Salt":                "salt",
+		"black_pepper":        "black_pepper",
+		"Black Pepper":        "black_pepper",
+		"pepper":              "black_pepper",
+		"Eggs":                "egg",
+		"apples":              "apple",
+		"Tomatoes":            "tomato",
+		"anchovies":           "anchovy",
+		"Garlic cloves":       "garlic",
+		"Parmigiano-Reggiano": "parmesan",
+		"Rolled Oats":         "oats",
+		"Hummus":              "hummus",
+		"Sumac":               "sumac",
+		"Green  beans":        "green_bean",
+		"radishes":            "radish",
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, ingredient.Resolve(name), name)
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := map[string]string{
+		"tomatoes":  "tomato",
+		"berries":   "berry",
+		"glasses":   "glass",
+		"radishes":  "radish",
+		"hummus":    "hummus",
+		"oats":      "oat",
+		"peas":      "pea",
+		"egg":       "egg",
+		"asparagus": "asparagus",
+	}
+
+	for word, expected := range tests {
+		assert.Equal(t, expected, ingredient.Singular(word), word)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	found, ok := ingredient.Lookup("olive_oil")
+	assert.True(t, ok)
+	assert.Equal(t, "Olive oil", found.Name)
+
+	_, ok = ingredient.Lookup("sumac")
+	assert.False(t, ok)
+}
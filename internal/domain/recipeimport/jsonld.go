@@ -0,0 +1,271 @@
+package recipeimport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+var (
+	ldScriptPattern = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+	headTagPattern  = regexp.MustCompile(`(?is)<(link|meta)\s[^>]*>`)
+	attrPattern     = regexp.MustCompile(`(?is)([a-z:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	blockTagPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(p|li|div|h[1-6])>`)
+	tagPattern      = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// parseJSONLD reads schema.org Recipe objects from a saved web page, taking
+// them from its application/ld+json scripts, or from a bare JSON-LD document.
+// Recipes may sit at the top level, in an array, in an @graph or as the
+// mainEntity of a page. The source URL is the recipe's own url, else the
+// page's canonical URL.
+func parseJSONLD(r io.Reader) ([]Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimSpace(string(data))
+
+	blocks := []string{text}
+	pageURL := ""
+	if strings.HasPrefix(text, "<") {
+		blocks = nil
+		for _, match := range ldScriptPattern.FindAllStringSubmatch(text, -1) {
+			blocks = append(blocks, match[1])
+		}
+		pageURL = canonicalURL(text)
+	}
+
+	// Pages carry unrelated JSON-LD too, so a broken block only matters when
+	// no recipe could be found anywhere else.
+	var results []Result
+	var decodeErr error
+	for _, block := range blocks {
+		var doc interface{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(block)), &doc); err != nil {
+			if decodeErr == nil {
+				decodeErr = fmt.Errorf("invalid JSON-LD: %w", err)
+			}
+			continue
+		}
+		for _, node := range findRecipes(doc) {
+			results = append(results, ldRecipe(node, pageURL))
+		}
+	}
+	if len(results) == 0 && decodeErr != nil {
+		return nil, decodeErr
+	}
+	return results, nil
+}
+
+// findRecipes collects the nodes typed as Recipe in a JSON-LD document.
+func findRecipes(doc interface{}) []map[string]interface{} {
+	var recipes []map[string]interface{}
+	switch node := doc.(type) {
+	case []interface{}:
+		for _, item := range node {
+			recipes = append(recipes, findRecipes(item)...)
+		}
+	case map[string]interface{}:
+		if isRecipe(node["@type"]) {
+			return append(recipes, node)
+		}
+		recipes = append(recipes, findRecipes(node["@graph"])...)
+		recipes = append(recipes, findRecipes(node["mainEntity"])...)
+	}
+	return recipes
+}
+
+func isRecipe(t interface{}) bool {
+	switch value := t.(type) {
+	case string:
+		name := value[strings.LastIndexAny(value, "/:")+1:]
+		return name == "Recipe"
+	case []interface{}:
+		for _, item := range value {
+			if isRecipe(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ldRecipe(node map[string]interface{}, pageURL string) Result {
+	recipe := &entity.Recipe{
+		Name:        ldText(node["name"]),
+		Description: ldText(node["description"]),
+	}
+	if recipe.Name == "" {
+		return Result{Err: errors.New("recipe has no name")}
+	}
+
+	lines := ldStrings(node["recipeIngredient"])
+	if len(lines) == 0 {
+		lines = ldStrings(node["ingredients"])
+	}
+	for _, line := range lines {
+		recipe.Ingredients = append(recipe.Ingredients, parseIngredientLine(line))
+	}
+	recipe.Steps = ldSteps(node["recipeInstructions"])
+
+	if servings, ok := parseServings(ldText(node["recipeYield"])); ok {
+		recipe.Servings = &servings
+	}
+	if minutes, ok := parseMinutes(ldText(node["prepTime"])); ok {
+		recipe.PrepTime = &minutes
+	}
+	if minutes, ok := parseMinutes(ldText(node["cookTime"])); ok {
+		recipe.CookTime = &minutes
+	}
+	if cuisine := ldText(node["recipeCuisine"]); cuisine != "" {
+		recipe.Cuisine = &cuisine
+	}
+	for _, source := range []string{ldURL(node["url"]), ldURL(node["mainEntityOfPage"]), pageURL} {
+		if source != "" {
+			recipe.SourceUrl = &source
+			break
+		}
+	}
+	return Result{Name: recipe.Name, Recipe: recipe}
+}
+
+// ldSteps flattens recipeInstructions, which may be one block of text, a
+// list of strings, HowToStep objects or HowToSection objects holding steps.
+func ldSteps(instructions interface{}) []entity.RecipeStep {
+	var steps []entity.RecipeStep
+	var walk func(interface{})
+	walk = func(value interface{}) {
+		switch node := value.(type) {
+		case string:
+			for _, line := range htmlLines(node) {
+				steps = append(steps, entity.RecipeStep{Text: line})
+			}
+		case []interface{}:
+			for _, item := range node {
+				walk(item)
+			}
+		case map[string]interface{}:
+			if items, ok := node["itemListElement"]; ok {
+				walk(items)
+				return
+			}
+			text := ldText(node["text"])
+			if text == "" {
+				text = ldText(node["name"])
+			}
+			if text == "" {
+				return
+			}
+			step := entity.RecipeStep{Text: text}
+			for _, key := range []string{"totalTime", "performTime"} {
+				if minutes, ok := parseMinutes(ldText(node[key])); ok {
+					step.DurationMinutes = &minutes
+					break
+				}
+			}
+			steps = append(steps, step)
+		}
+	}
+	walk(instructions)
+	return steps
+}
+
+// ldText reads a JSON-LD value as plain text: strings lose their markup,
+// numbers are formatted, lists give their first value and objects their
+// @value or name.
+func ldText(value interface{}) string {
+	switch node := value.(type) {
+	case string:
+		return strings.Join(htmlLines(node), " ")
+	case float64:
+		return strconv.FormatFloat(node, 'f', -1, 64)
+	case []interface{}:
+		for _, item := range node {
+			if text := ldText(item); text != "" {
+				return text
+			}
+		}
+	case map[string]interface{}:
+		if text := ldText(node["@value"]); text != "" {
+			return text
+		}
+		return ldText(node["name"])
+	}
+	return ""
+}
+
+func ldStrings(value interface{}) []string {
+	switch node := value.(type) {
+	case string:
+		return htmlLines(node)
+	case []interface{}:
+		var texts []string
+		for _, item := range node {
+			if text := ldText(item); text != "" {
+				texts = append(texts, text)
+			}
+		}
+		return texts
+	}
+	return nil
+}
+
+// ldURL reads an absolute URL given directly or as a node's @id or url.
+func ldURL(value interface{}) string {
+	var url string
+	switch node := value.(type) {
+	case string:
+		url = strings.TrimSpace(node)
+	case map[string]interface{}:
+		if url = ldURL(node["@id"]); url == "" {
+			url = ldURL(node["url"])
+		}
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return ""
+	}
+	return url
+}
+
+// canonicalURL finds the page's canonical link, falling back to og:url.
+func canonicalURL(page string) string {
+	var canonical, og string
+	for _, match := range headTagPattern.FindAllStringSubmatch(page, -1) {
+		attrs := map[string]string{}
+		for _, attr := range attrPattern.FindAllStringSubmatch(match[0], -1) {
+			attrs[strings.ToLower(attr[1])] = html.UnescapeString(attr[2] + attr[3])
+		}
+		switch {
+		case strings.EqualFold(match[1], "link") && strings.EqualFold(attrs["rel"], "canonical"):
+			canonical = ldURL(attrs["href"])
+		case strings.EqualFold(match[1], "meta") && attrs["property"] == "og:url":
+			og = ldURL(attrs["content"])
+		}
+	}
+	if canonical != "" {
+		return canonical
+	}
+	return og
+}
+
+// htmlLines turns text that may contain HTML into trimmed, non-empty lines,
+// breaking at line breaks, paragraphs and list items.
+func htmlLines(text string) []string {
+	text = blockTagPattern.ReplaceAllString(text, "\n")
+	text = html.UnescapeString(tagPattern.ReplaceAllString(text, ""))
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
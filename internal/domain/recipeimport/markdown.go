@@ -0,0 +1,211 @@
+package recipeimport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+var (
+	mdHeadingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdListPattern     = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(.*)$`)
+	mdMetadataPattern = regexp.MustCompile(`^[*_]*([A-Za-z][A-Za-z ]*?)[*_]*\s*:[*_]*\s*(.+?)\s*$`)
+	mdLinkPattern     = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]*)[^)]*\)`)
+)
+
+type mdSection int
+
+const (
+	mdIntro mdSection = iota
+	mdIngredients
+	mdSteps
+	mdOther
+)
+
+// mdRecipe is a Markdown recipe being read. The first invalid metadata value
+// fails the recipe.
+type mdRecipe struct {
+	recipe      *entity.Recipe
+	section     mdSection
+	description []string
+	paragraph   []string
+	continuing  bool
+	err         error
+}
+
+// parseMarkdown reads recipes written in this Markdown convention:
+//
+//	# Pancakes
+//	Source: https://example.com/pancakes
+//	Servings: 4
+//	Prep time: 10 min
+//	Cook time: 20 min
+//	Cuisine: American
+//
+//	Fluffy weekend pancakes.
+//
+//	## Ingredients
+//	- 2 cups flour
+//	- 1 tbsp sugar (optional)
+//
+//	## Steps
+//	1. Whisk everything together.
+//	2. Fry in a hot pan.
+//
+// Every level-one heading starts a recipe. Text before the first section is
+// the description, apart from metadata lines; the method may also be written
+// as paragraphs. Unknown sections such as notes are skipped, and deeper
+// headings only group items within a section.
+func parseMarkdown(r io.Reader) ([]Result, error) {
+	var results []Result
+	var current *mdRecipe
+	flush := func() {
+		if current != nil {
+			results = append(results, current.result())
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if match := mdHeadingPattern.FindStringSubmatch(line); match != nil && len(match[1]) == 1 {
+			flush()
+			current = &mdRecipe{recipe: &entity.Recipe{Name: mdInline(match[2])}}
+			continue
+		}
+		if current != nil {
+			current.add(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return results, nil
+}
+
+func (m *mdRecipe) add(line string) {
+	if match := mdHeadingPattern.FindStringSubmatch(line); match != nil {
+		m.endParagraph()
+		if len(match[1]) == 2 {
+			m.section = mdSectionOf(match[2])
+		}
+		return
+	}
+	if strings.TrimSpace(line) == "" {
+		m.endParagraph()
+		m.continuing = false
+		return
+	}
+
+	switch m.section {
+	case mdIntro:
+		if !m.metadata(line) {
+			m.paragraph = append(m.paragraph, mdInline(line))
+		}
+	case mdIngredients:
+		if match := mdListPattern.FindStringSubmatch(line); match != nil {
+			m.recipe.Ingredients = append(m.recipe.Ingredients, parseIngredientLine(mdInline(match[1])))
+		}
+	case mdSteps:
+		text := line
+		if match := mdListPattern.FindStringSubmatch(line); match != nil {
+			text, m.continuing = match[1], false
+		}
+		text = mdInline(text)
+		if last := len(m.recipe.Steps) - 1; m.continuing && last >= 0 {
+			m.recipe.Steps[last].Text += " " + text
+		} else {
+			m.recipe.Steps = append(m.recipe.Steps, entity.RecipeStep{Text: text})
+		}
+		m.continuing = true
+	}
+}
+
+// metadata applies a "Key: value" line and reports whether it was one.
+func (m *mdRecipe) metadata(line string) bool {
+	match := mdMetadataPattern.FindStringSubmatch(line)
+	if match == nil {
+		return false
+	}
+	key, value := strings.ToLower(match[1]), match[2]
+	recipe := m.recipe
+	var err error
+	switch key {
+	case "source", "source url", "url":
+		if link := mdLinkPattern.FindStringSubmatch(value); link != nil {
+			value = link[2]
+		}
+		recipe.SourceUrl = &value
+	case "servings", "serves", "yield":
+		recipe.Servings, err = mdNumber(key, value, parseServings)
+	case "prep", "prep time", "preparation time":
+		recipe.PrepTime, err = mdNumber(key, value, parseMinutes)
+	case "cook", "cook time", "cooking time":
+		recipe.CookTime, err = mdNumber(key, value, parseMinutes)
+	case "cuisine":
+		recipe.Cuisine = &value
+	case "difficulty":
+		recipe.Difficulty, err = mdNumber(key, value, func(text string) (int, bool) {
+			n, err := strconv.Atoi(text)
+			return n, err == nil
+		})
+	default:
+		return false
+	}
+	if err != nil && m.err == nil {
+		m.err = err
+	}
+	return true
+}
+
+func mdNumber(key, value string, parse func(string) (int, bool)) (*int, error) {
+	n, ok := parse(value)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s %q", key, value)
+	}
+	return &n, nil
+}
+
+// endParagraph closes a paragraph of the description.
+func (m *mdRecipe) endParagraph() {
+	if len(m.paragraph) > 0 {
+		m.description = append(m.description, strings.Join(m.paragraph, " "))
+		m.paragraph = nil
+	}
+}
+
+func (m *mdRecipe) result() Result {
+	m.endParagraph()
+	m.recipe.Description = strings.Join(m.description, "\n\n")
+	result := Result{Name: m.recipe.Name, Recipe: m.recipe, Err: m.err}
+	if m.err != nil {
+		result.Recipe = nil
+	}
+	return result
+}
+
+func mdSectionOf(heading string) mdSection {
+	heading = strings.ToLower(heading)
+	switch {
+	case strings.Contains(heading, "ingredient"):
+		return mdIngredients
+	case strings.Contains(heading, "step"), strings.Contains(heading, "instruction"),
+		strings.Contains(heading, "direction"), strings.Contains(heading, "method"):
+		return mdSteps
+	}
+	return mdOther
+}
+
+// mdInline drops inline Markdown: emphasis, code spans and links, keeping
+// the link text.
+func mdInline(text string) string {
+	text = mdLinkPattern.ReplaceAllString(text, "$1")
+	text = strings.NewReplacer("**", "", "__", "", "`", "").Replace(text)
+	return strings.TrimSpace(text)
+}
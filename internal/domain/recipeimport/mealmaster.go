@@ -0,0 +1,191 @@
+package recipeimport
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/units"
+)
+
+var (
+	mmHeaderPattern  = regexp.MustCompile(`(?i)^(MMMMM|-----).*meal-master`)
+	mmEndPattern     = regexp.MustCompile(`^(MMMMM|-----)\s*$`)
+	mmSectionPattern = regexp.MustCompile(`^(MMMMM)?-{5,}`)
+	mmFieldPattern   = regexp.MustCompile(`(?i)^\s*(title|categories|yield|servings)\s*:\s*(.*?)\s*$`)
+)
+
+// mmUnits maps Meal-Master's two-letter unit codes to unit names. Codes are
+// case sensitive: "t" is a teaspoon and "T" a tablespoon. An empty name means
+// the quantity is a count.
+var mmUnits = map[string]string{
+	"x": "", "ea": "",
+	"t": "tsp", "ts": "tsp", "T": "tbsp", "tb": "tbsp",
+	"fl": "fl oz", "c": "cup", "pt": "pt", "qt": "qt", "ga": "gal",
+	"oz": "oz", "lb": "lb", "mg": "mg", "g": "g", "kg": "kg",
+	"ml": "ml", "cb": "ml", "cl": "cl", "dl": "dl", "l": "l",
+	"cn": "can", "pk": "package", "pn": "pinch", "dr": "drop", "ds": "dash",
+	"ct": "carton", "bn": "bunch", "sl": "slice",
+}
+
+// mmSizes are the size codes, which describe the ingredient rather than
+// measure it.
+var mmSizes = map[string]string{"sm": "small", "md": "medium", "lg": "large"}
+
+type mmPhase int
+
+const (
+	mmHeader mmPhase = iota
+	mmIngredients
+	mmDirections
+)
+
+// mmRecipe is a Meal-Master recipe being read.
+type mmRecipe struct {
+	recipe    *entity.Recipe
+	phase     mmPhase
+	paragraph []string
+}
+
+// parseMealMaster reads Meal-Master text exports. Each recipe starts with a
+// "MMMMM----- Recipe via Meal-Master" or "----- Recipe via Meal-Master" line
+// and ends with a line of just "MMMMM" or "-----". Its Title, Categories and
+// Yield or Servings fields are followed by ingredients in fixed columns —
+// quantity in the first seven, unit code in the next two after a space, and
+// the ingredient from the twelfth — and then the directions, one step per
+// paragraph. Ingredient lines starting with "-" continue the previous one.
+func parseMealMaster(r io.Reader) ([]Result, error) {
+	var results []Result
+	var current *mmRecipe
+	flush := func() {
+		if current != nil {
+			results = append(results, current.result())
+			current = nil
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		switch {
+		case mmHeaderPattern.MatchString(line):
+			flush()
+			current = &mmRecipe{recipe: &entity.Recipe{}}
+		case current == nil:
+		case mmEndPattern.MatchString(line):
+			flush()
+		default:
+			current.add(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	// A recipe cut off before its end line is kept.
+	flush()
+	return results, nil
+}
+
+func (m *mmRecipe) add(line string) {
+	if strings.TrimSpace(line) == "" {
+		m.endParagraph()
+		return
+	}
+	if m.phase == mmHeader {
+		if match := mmFieldPattern.FindStringSubmatch(line); match != nil {
+			m.field(strings.ToLower(match[1]), match[2])
+			return
+		}
+		m.phase = mmIngredients
+	}
+	if mmSectionPattern.MatchString(line) {
+		return
+	}
+	if m.phase == mmIngredients {
+		if m.ingredient(line) {
+			return
+		}
+		m.phase = mmDirections
+	}
+	m.paragraph = append(m.paragraph, strings.TrimSpace(line))
+}
+
+func (m *mmRecipe) field(key, value string) {
+	switch key {
+	case "title":
+		m.recipe.Name = value
+	case "yield", "servings":
+		if servings, ok := parseServings(value); ok {
+			m.recipe.Servings = &servings
+		}
+	}
+}
+
+// ingredient reads a line in the ingredient columns and reports whether it
+// was one.
+func (m *mmRecipe) ingredient(line string) bool {
+	if len(line) < 12 || line[7] != ' ' || line[10] != ' ' {
+		return false
+	}
+	amount := strings.TrimSpace(line[:7])
+	code := strings.TrimSpace(line[8:10])
+	name := strings.TrimSpace(line[11:])
+
+	ingredients := m.recipe.Ingredients
+	if amount == "" && code == "" && strings.HasPrefix(name, "-") {
+		if len(ingredients) == 0 {
+			return false
+		}
+		last := &ingredients[len(ingredients)-1]
+		preparation := strings.TrimSpace(strings.TrimLeft(name, "-"))
+		if last.Preparation != nil {
+			preparation = *last.Preparation + " " + preparation
+		}
+		last.Preparation = &preparation
+		return true
+	}
+
+	item := entity.RecipeIngredient{}
+	if amount != "" {
+		q, rest, ok := units.Cut(amount)
+		if !ok || rest != "" {
+			return false
+		}
+		item.Quantity = &q.Amount
+	}
+	if size, ok := mmSizes[code]; ok {
+		name = size + " " + name
+	} else if unit, ok := mmUnits[code]; ok {
+		if unit != "" && item.Quantity != nil {
+			item.Unit = &unit
+		}
+	} else if code != "" {
+		return false
+	}
+
+	name, preparation, _ := strings.Cut(name, ",")
+	item.Name = strings.TrimSpace(name)
+	if preparation = strings.TrimSpace(preparation); preparation != "" {
+		item.Preparation = &preparation
+	}
+	m.recipe.Ingredients = append(ingredients, item)
+	return true
+}
+
+func (m *mmRecipe) endParagraph() {
+	if len(m.paragraph) > 0 {
+		m.recipe.Steps = append(m.recipe.Steps, entity.RecipeStep{Text: strings.Join(m.paragraph, " ")})
+		m.paragraph = nil
+	}
+}
+
+func (m *mmRecipe) result() Result {
+	m.endParagraph()
+	if m.recipe.Name == "" {
+		return Result{Err: errors.New("recipe has no title")}
+	}
+	return Result{Name: m.recipe.Name, Recipe: m.recipe}
+}
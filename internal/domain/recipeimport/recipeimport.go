@@ -0,0 +1,142 @@
+// Package recipeimport reads recipes from the formats recipe sites and
+// recipe managers publish them in: schema.org Recipe JSON-LD embedded in
+// saved web pages, a simple Markdown convention and Meal-Master text.
+//
+// Parsers only read the files. Validation and ingredient resolution are left
+// to the caller, which treats imported recipes like any other new recipe.
+package recipeimport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/units"
+)
+
+// ErrNoRecipes is returned when a file holds nothing that looks like a recipe.
+var ErrNoRecipes = errors.New("no recipes found in import file")
+
+// Result is one recipe read from an import file, numbered from 1 in file
+// order. Either Recipe or Err is set. Name is filled in whenever the title
+// could be read, so failures can be reported by name.
+type Result struct {
+	Index  int
+	Name   string
+	Recipe *entity.Recipe
+	Err    error
+}
+
+// Parse reads every recipe in r. A malformed recipe fails only its own
+// result; an error is returned when the file as a whole cannot be read.
+func Parse(r io.Reader, format entity.RecipeImportFormat) ([]Result, error) {
+	var results []Result
+	var err error
+	switch format {
+	case entity.RecipeImportFormatJSONLd:
+		results, err = parseJSONLD(r)
+	case entity.RecipeImportFormatMarkdown:
+		results, err = parseMarkdown(r)
+	case entity.RecipeImportFormatMealmaster:
+		results, err = parseMealMaster(r)
+	default:
+		return nil, fmt.Errorf("unsupported recipe format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, ErrNoRecipes
+	}
+	for i := range results {
+		results[i].Index = i + 1
+	}
+	return results, nil
+}
+
+var (
+	optionalPattern = regexp.MustCompile(`(?i)\s*\(optional\)|,\s*optional\s*$`)
+	isoDuration     = regexp.MustCompile(`(?i)^P(?:(\d+)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+	textDuration    = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(hours?|hrs?|h|minutes?|mins?|m)\b`)
+	firstNumber     = regexp.MustCompile(`\d+`)
+)
+
+// parseIngredientLine reads a free-text ingredient line such as
+// "2 cups all-purpose flour, sifted" or "1 tsp vanilla (optional)". Text after
+// the first comma is the preparation.
+func parseIngredientLine(line string) entity.RecipeIngredient {
+	var item entity.RecipeIngredient
+	line = strings.Join(strings.Fields(line), " ")
+	if stripped := optionalPattern.ReplaceAllString(line, ""); stripped != line {
+		item.Optional = true
+		line = stripped
+	}
+
+	if q, rest, ok := units.Cut(line); ok {
+		amount := q.Amount
+		item.Quantity = &amount
+		if q.Unit != units.Piece {
+			unit := q.Unit.Symbol
+			item.Unit = &unit
+		}
+		line = strings.TrimPrefix(rest, "of ")
+	}
+
+	name, preparation, _ := strings.Cut(line, ",")
+	item.Name = strings.TrimSpace(name)
+	if preparation = strings.TrimSpace(preparation); preparation != "" {
+		item.Preparation = &preparation
+	}
+	return item
+}
+
+// parseMinutes reads a duration as ISO 8601 ("PT1H30M"), as text ("1 hr 30
+// min", "45 minutes") or as a bare number of minutes.
+func parseMinutes(text string) (int, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, false
+	}
+	if minutes, err := strconv.Atoi(text); err == nil && minutes >= 0 {
+		return minutes, true
+	}
+
+	total := 0.0
+	if match := isoDuration.FindStringSubmatch(text); match != nil {
+		for i, perUnit := range []float64{24 * 60, 60, 1, 1.0 / 60} {
+			if match[i+1] != "" {
+				value, _ := strconv.ParseFloat(match[i+1], 64)
+				total += value * perUnit
+			}
+		}
+		return int(math.Round(total)), true
+	}
+
+	matches := textDuration.FindAllStringSubmatch(text, -1)
+	if matches == nil {
+		return 0, false
+	}
+	for _, match := range matches {
+		value, _ := strconv.ParseFloat(match[1], 64)
+		if strings.HasPrefix(strings.ToLower(match[2]), "h") {
+			value *= 60
+		}
+		total += value
+	}
+	return int(math.Round(total)), true
+}
+
+// parseServings reads the first whole number of a yield such as "4",
+// "Serves 6" or "12 cookies".
+func parseServings(text string) (int, bool) {
+	servings, err := strconv.Atoi(firstNumber.FindString(text))
+	if err != nil || servings <= 0 {
+		return 0, false
+	}
+	return servings, true
+}
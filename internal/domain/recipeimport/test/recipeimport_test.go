@@ -0,0 +1,234 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/recipeimport"
+)
+
+const savedPage = `<!DOCTYPE html>
+<html>
+<head>
+  <link rel="canonical" href="https://example.com/recipes/shakshuka">
+  <script type="application/ld+json">{"@context": "https://schema.org", "@type": "BreadcrumbList"}</script>
+  <script type="application/ld+json">
+  {
+    "@context": "https://schema.org",
+    "@graph": [
+      {"@type": "WebPage", "@id": "https://example.com/recipes/shakshuka"},
+      {
+        "@type": ["Recipe", "NewsArticle"],
+        "name": "Shakshuka",
+        "description": "<p>Eggs poached in a spiced tomato &amp; pepper sauce.</p>",
+        "recipeYield": ["4", "4 servings"],
+        "prepTime": "PT10M",
+        "cookTime": "PT1H5M",
+        "recipeCuisine": ["Middle Eastern"],
+        "recipeIngredient": [
+          "2 tbsp olive oil",
+          "1 onion, diced",
+          "6 eggs",
+          "1 tsp chili flakes (optional)"
+        ],
+        "recipeInstructions": [
+          {"@type": "HowToSection", "name": "Sauce", "itemListElement": [
+            {"@type": "HowToStep", "text": "Soften the onion in the oil."},
+            {"@type": "HowToStep", "text": "Simmer the sauce.", "performTime": "PT20M"}
+          ]},
+          {"@type": "HowToStep", "text": "Crack in the eggs and cover."}
+        ]
+      }
+    ]
+  }
+  </script>
+</head>
+<body></body>
+</html>`
+
+func TestParse_JSONLDFromSavedPage(t *testing.T) {
+	results, err := recipeimport.Parse(strings.NewReader(savedPage), entity.RecipeImportFormatJSONLd)
+
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 1) {
+		return
+	}
+	assert.NoError(t, results[0].Err)
+	recipe := results[0].Recipe
+	assert.Equal(t, "Shakshuka", recipe.Name)
+	assert.Equal(t, "Eggs poached in a spiced tomato & pepper sauce.", recipe.Description)
+	assert.Equal(t, "https://example.com/recipes/shakshuka", *recipe.SourceUrl)
+	assert.Equal(t, 4, *recipe.Servings)
+	assert.Equal(t, 10, *recipe.PrepTime)
+	assert.Equal(t, 65, *recipe.CookTime)
+	assert.Equal(t, "Middle Eastern", *recipe.Cuisine)
+
+	if !assert.Len(t, recipe.Ingredients, 4) {
+		return
+	}
+	assert.Equal(t, "olive oil", recipe.Ingredients[0].Name)
+	assert.Equal(t, 2.0, *recipe.Ingredients[0].Quantity)
+	assert.Equal(t, "tbsp", *recipe.Ingredients[0].Unit)
+	assert.Equal(t, "onion", recipe.Ingredients[1].Name)
+	assert.Equal(t, "diced", *recipe.Ingredients[1].Preparation)
+	assert.Nil(t, recipe.Ingredients[2].Unit)
+	assert.True(t, recipe.Ingredients[3].Optional)
+	assert.Equal(t, "chili flakes", recipe.Ingredients[3].Name)
+
+	if !assert.Len(t, recipe.Steps, 3) {
+		return
+	}
+	assert.Equal(t, "Simmer the sauce.", recipe.Steps[1].Text)
+	assert.Equal(t, 20, *recipe.Steps[1].DurationMinutes)
+}
+
+func TestParse_JSONLDKeepsRecipeURL(t *testing.T) {
+	doc := `[{"@type": "Recipe", "name": "Toast", "url": "https://example.com/toast",
+		"recipeIngredient": ["2 slices bread"], "recipeInstructions": "Toast the bread.\nButter it."}]`
+
+	results, err := recipeimport.Parse(strings.NewReader(doc), entity.RecipeImportFormatJSONLd)
+
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 1) {
+		return
+	}
+	recipe := results[0].Recipe
+	assert.Equal(t, "https://example.com/toast", *recipe.SourceUrl)
+	assert.Len(t, recipe.Steps, 2)
+}
+
+func TestParse_JSONLDWithoutRecipes(t *testing.T) {
+	page := `<html><script type="application/ld+json">{"@type": "Organization"}</script></html>`
+
+	_, err := recipeimport.Parse(strings.NewReader(page), entity.RecipeImportFormatJSONLd)
+
+	assert.ErrorIs(t, err, recipeimport.ErrNoRecipes)
+}
+
+func TestParse_Markdown(t *testing.T) {
+	doc := strings.Join([]string{
+		"# Pancakes",
+		"**Source:** [My blog](https://example.com/pancakes)",
+		"Servings: 4",
+		"Prep time: 1 hr 15 min",
+		"",
+		"Fluffy weekend",
+		"pancakes.",
+		"",
+		"## Ingredients",
+		"- 2 cups flour",
+		"- 1 tbsp sugar (optional)",
+		"",
+		"## Steps",
+		"1. Whisk everything",
+		"   together.",
+		"2. Fry in a hot pan.",
+		"",
+		"## Notes",
+		"Freezes well.",
+		"",
+		"# Mystery stew",
+		"Servings: lots",
+		"## Ingredients",
+		"- stuff",
+	}, "\n")
+
+	results, err := recipeimport.Parse(strings.NewReader(doc), entity.RecipeImportFormatMarkdown)
+
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 2) {
+		return
+	}
+	recipe := results[0].Recipe
+	assert.Equal(t, "Pancakes", recipe.Name)
+	assert.Equal(t, "https://example.com/pancakes", *recipe.SourceUrl)
+	assert.Equal(t, 4, *recipe.Servings)
+	assert.Equal(t, 75, *recipe.PrepTime)
+	assert.Equal(t, "Fluffy weekend pancakes.", recipe.Description)
+	if !assert.Len(t, recipe.Ingredients, 2) {
+		return
+	}
+	assert.Equal(t, "cup", *recipe.Ingredients[0].Unit)
+	assert.True(t, recipe.Ingredients[1].Optional)
+	if !assert.Len(t, recipe.Steps, 2) {
+		return
+	}
+	assert.Equal(t, "Whisk everything together.", recipe.Steps[0].Text)
+
+	assert.Equal(t, 2, results[1].Index)
+	assert.Equal(t, "Mystery stew", results[1].Name)
+	assert.EqualError(t, results[1].Err, `invalid servings "lots"`)
+	assert.Nil(t, results[1].Recipe)
+}
+
+const mealMaster = `MMMMM----- Recipe via Meal-Master (tm) v8.05
+
+      Title: Oatmeal Cookies
+ Categories: Cookies, Desserts
+      Yield: 36 cookies
+
+      1 c  Butter, softened
+    3/4 c  Brown sugar
+  1 1/2 c  Rolled oats
+      1 ts Vanilla
+      1 lg Egg
+MMMMM--------------------------TOPPING-------------------------
+           Coarse sugar
+           -for sprinkling
+
+  Cream the butter and sugar, then beat in the
+  egg and vanilla.
+
+  Stir in the oats and bake for 12 minutes.
+
+MMMMM
+
+MMMMM----- Recipe via Meal-Master (tm) v8.05
+
+ Categories: Untitled
+
+      2 c  Water
+MMMMM
+`
+
+func TestParse_MealMaster(t *testing.T) {
+	results, err := recipeimport.Parse(strings.NewReader(mealMaster), entity.RecipeImportFormatMealmaster)
+
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 2) {
+		return
+	}
+	recipe := results[0].Recipe
+	assert.Equal(t, "Oatmeal Cookies", recipe.Name)
+	assert.Equal(t, 36, *recipe.Servings)
+
+	if !assert.Len(t, recipe.Ingredients, 6) {
+		return
+	}
+	assert.Equal(t, "Butter", recipe.Ingredients[0].Name)
+	assert.Equal(t, "softened", *recipe.Ingredients[0].Preparation)
+	assert.Equal(t, 0.75, *recipe.Ingredients[1].Quantity)
+	assert.Equal(t, 1.5, *recipe.Ingredients[2].Quantity)
+	assert.Equal(t, "cup", *recipe.Ingredients[2].Unit)
+	assert.Equal(t, "tsp", *recipe.Ingredients[3].Unit)
+	assert.Equal(t, "large Egg", recipe.Ingredients[4].Name)
+	assert.Nil(t, recipe.Ingredients[4].Unit)
+	assert.Nil(t, recipe.Ingredients[5].Quantity)
+	assert.Equal(t, "for sprinkling", *recipe.Ingredients[5].Preparation)
+
+	if !assert.Len(t, recipe.Steps, 2) {
+		return
+	}
+	assert.Equal(t, "Cream the butter and sugar, then beat in the egg and vanilla.", recipe.Steps[0].Text)
+
+	assert.EqualError(t, results[1].Err, "recipe has no title")
+}
+
+func TestParse_UnsupportedFormat(t *testing.T) {
+	_, err := recipeimport.Parse(strings.NewReader(""), entity.RecipeImportFormat("PDF"))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import "errors"
+
+var (
+	ErrPantryNotFound       = errors.New("pantry not found")
+	ErrPantryEntryNotFound  = errors.New("pantry entry not found")
+	ErrInsufficientQuantity = errors.New("insufficient quantity on hand")
+	ErrInviteNotFound       = errors.New("invite not found, expired or already used")
+	ErrLocationNotFound     = errors.New("storage location not found")
+	ErrProductNotFound      = errors.New("product not found in catalog")
+	ErrShoppingListNotFound = errors.New("shopping list not found")
+	ErrShoppingItemNotFound = errors.New("shopping list item not found")
+	ErrRecipeNotFound       = errors.New("recipe not found")
+	ErrMealPlanNotFound     = errors.New("meal plan not found")
+	ErrSearchUnsupported    = errors.New("text search is not supported by this store")
+	ErrRatingNotFound       = errors.New("rating not found")
+)
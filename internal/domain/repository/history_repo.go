@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+// HistoryRepository stores the audit trail. Records are append-only; there is
+// deliberately no way to change or remove one.
+type HistoryRepository interface {
+	AppendHistory(ctx context.Context, record *entity.HistoryRecord) error
+	// GetHistory returns the records of a pantry, newest first, optionally
+	// limited to those at or after since and to a single entry.
+	GetHistory(ctx context.Context, pantryID string, since *time.Time, entryID *string) ([]entity.HistoryRecord, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+type InviteRepository interface {
+	CreateInvite(ctx context.Context, invite *entity.PantryInvite) error
+	GetInvite(ctx context.Context, code string) (*entity.PantryInvite, error)
+	AcceptInvite(ctx context.Context, code string, userID string, now time.Time) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+type MealPlanRepository interface {
+	CreateMealPlan(ctx context.Context, plan *entity.MealPlan) error
+	GetMealPlan(ctx context.Context, planID string) (*entity.MealPlan, error)
+	// GetMealPlans returns the plans of a pantry dated within [from, to],
+	// ordered by date.
+	GetMealPlans(ctx context.Context, pantryID string, from time.Time, to time.Time) ([]entity.MealPlan, error)
+	DeleteMealPlan(ctx context.Context, planID string) error
+}
@@ -2,14 +2,34 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/thisausername99/pantry_butler/internal/domain/entity"
 )
 
 type PantryRepository interface {
 	GetPantryEntries(ctx context.Context, pantryID string) ([]entity.PantryEntry, error)
+	FindPantryEntries(ctx context.Context, pantryID string, filter *entity.PantryEntryFilter) ([]entity.PantryEntry, error)
 	InsertPantryEntry(ctx context.Context, pantryID string, entry *entity.PantryEntry) error
+	// InsertPantryEntries adds several entries in a single write.
+	InsertPantryEntries(ctx context.Context, pantryID string, entries []entity.PantryEntry) error
+	// IncrementPantryEntry adds amount to an entry's quantity. When expiration
+	// is given the entry keeps whichever of the two expirations is earlier.
+	IncrementPantryEntry(ctx context.Context, pantryID string, entryID string, amount float64, expiration *time.Time) error
+	// MergePantryEntries replaces the entry with keep.ID by keep and removes
+	// the entries in removeIDs, in a single write.
+	MergePantryEntries(ctx context.Context, pantryID string, keep *entity.PantryEntry, removeIDs []string) error
+	UpdatePantryEntry(ctx context.Context, pantryID string, entryID string, patch *entity.PantryEntryPatch) error
+	ConsumePantryEntry(ctx context.Context, pantryID string, entryID string, amount float64) (bool, error)
 	DeletePantryEntry(ctx context.Context, pantryID string, entryID string) error
+	GetPantry(ctx context.Context, pantryID string) (*entity.Pantry, error)
 	CreateNewPantry(ctx context.Context, pantry *entity.Pantry) error
+	AddPantryMember(ctx context.Context, pantryID string, member *entity.PantryMember) error
+	RemovePantryMember(ctx context.Context, pantryID string, userID string) error
+	AddStorageLocation(ctx context.Context, pantryID string, location *entity.StorageLocation) error
+	RemoveStorageLocation(ctx context.Context, pantryID string, locationID string) error
+	MovePantryEntry(ctx context.Context, pantryID string, entryID string, locationID string) error
+	TagPantryEntry(ctx context.Context, pantryID string, entryID string, tags []string) error
+	UntagPantryEntry(ctx context.Context, pantryID string, entryID string, tags []string) error
 	DeletePantry(ctx context.Context, pantryID string) error
 }
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+type ProductRepository interface {
+	GetProduct(ctx context.Context, code string) (*entity.Product, error)
+	UpsertProduct(ctx context.Context, product *entity.Product) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+type RatingRepository interface {
+	// UpsertRating stores a user's rating of a recipe, replacing any earlier
+	// one, and returns the rating it replaced or nil if there was none.
+	UpsertRating(ctx context.Context, rating *entity.RecipeRating) (*entity.RecipeRating, error)
+	GetRating(ctx context.Context, recipeID string, userID string) (*entity.RecipeRating, error)
+	DeleteRatings(ctx context.Context, recipeID string) error
+}
@@ -8,5 +8,19 @@ import (
 
 type RecipeRepository interface {
 	GetRecipes(ctx context.Context) ([]entity.Recipe, error)
+	GetRecipe(ctx context.Context, recipeID string) (*entity.Recipe, error)
 	GetRecipesByCuisine(ctx context.Context, cuisine string) ([]entity.Recipe, error)
+	// GetRecipesByIDs returns the recipes with the given IDs in one query.
+	// IDs with no recipe are skipped.
+	GetRecipesByIDs(ctx context.Context, recipeIDs []string) ([]entity.Recipe, error)
+	CreateRecipe(ctx context.Context, recipe *entity.Recipe) error
+	UpdateRecipe(ctx context.Context, recipe *entity.Recipe) error
+	DeleteRecipe(ctx context.Context, recipeID string) error
+	// SearchRecipes returns up to limit recipes matching query, most relevant
+	// first, skipping the first offset. Stores that cannot search return
+	// ErrSearchUnsupported.
+	SearchRecipes(ctx context.Context, query string, offset int, limit int) ([]entity.RecipeSearchHit, error)
+	// AdjustRecipeRating adds starsDelta to a recipe's rating total and
+	// countDelta to its rating count, then recomputes the average.
+	AdjustRecipeRating(ctx context.Context, recipeID string, starsDelta int, countDelta int) error
 }
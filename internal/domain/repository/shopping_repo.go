@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+type ShoppingListRepository interface {
+	CreateShoppingList(ctx context.Context, list *entity.ShoppingList) error
+	GetShoppingList(ctx context.Context, listID string) (*entity.ShoppingList, error)
+	GetShoppingLists(ctx context.Context, pantryID string) ([]entity.ShoppingList, error)
+	AddShoppingListItem(ctx context.Context, listID string, item *entity.ShoppingListItem) error
+	// SetShoppingListItemChecked reports whether the item changed state; it
+	// returns false without error when the item was already in that state.
+	SetShoppingListItemChecked(ctx context.Context, listID string, itemID string, checked bool, at time.Time) (bool, error)
+	ClearShoppingListItems(ctx context.Context, listID string, checkedOnly bool) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+type SubstitutionRepository interface {
+	// GetSubstitutions returns the rules replacing any of the given
+	// ingredients, by canonical ingredient ID.
+	GetSubstitutions(ctx context.Context, ingredientIDs []string) ([]entity.Substitution, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// Transactor runs a unit of work atomically. Repository calls made with the
+// context handed to fn take part in the transaction, and every write is
+// rolled back when fn returns an error. fn may be retried on transient
+// errors, so it must not have side effects outside the repositories.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
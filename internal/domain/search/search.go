@@ -0,0 +1,107 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/ingredient"
+)
+
+// Field weights of the recipe text index. They must match the weights in
+// the migration that creates the index so both implementations rank alike.
+const (
+	NameWeight        = 10
+	IngredientWeight  = 5
+	DescriptionWeight = 1
+)
+
+// stopWords are ignored in queries and text, as MongoDB's English text
+// search does.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"by": true, "for": true, "from": true, "in": true, "is": true, "it": true,
+	"of": true, "on": true, "or": true, "the": true, "to": true, "with": true,
+}
+
+// Terms splits text into lower-case, singular search terms, dropping stop
+// words and duplicates.
+func Terms(text string) []string {
+	seen := map[string]bool{}
+	var terms []string
+	for _, token := range tokenize(text) {
+		if !seen[token] {
+			seen[token] = true
+			terms = append(terms, token)
+		}
+	}
+	return terms
+}
+
+// Score rates how well a recipe matches the query terms, following MongoDB's
+// text scoring: each field contributes its weight for every matched term,
+// scaled by how often the term occurs relative to the field's length. A
+// recipe matching no term scores zero.
+func Score(recipe *entity.Recipe, terms []string) float64 {
+	ingredients := make([]string, len(recipe.Ingredients))
+	for i := range recipe.Ingredients {
+		ingredients[i] = recipe.Ingredients[i].Name
+	}
+	return fieldScore(recipe.Name, terms, NameWeight) +
+		fieldScore(strings.Join(ingredients, " "), terms, IngredientWeight) +
+		fieldScore(recipe.Description, terms, DescriptionWeight)
+}
+
+// Recipes returns the recipes matching query, most relevant first. Ties are
+// broken by recipe ID so that paging through the results is stable.
+func Recipes(recipes []entity.Recipe, query string) []entity.RecipeSearchHit {
+	terms := Terms(query)
+	hits := []entity.RecipeSearchHit{}
+	if len(terms) == 0 {
+		return hits
+	}
+	for i := range recipes {
+		if score := Score(&recipes[i], terms); score > 0 {
+			hits = append(hits, entity.RecipeSearchHit{Recipe: recipes[i], Score: score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Recipe.ID < hits[j].Recipe.ID
+	})
+	return hits
+}
+
+func fieldScore(text string, terms []string, weight float64) float64 {
+	tokens := tokenize(text)
+	if len(tokens) == 0 {
+		return 0
+	}
+	counts := map[string]int{}
+	for _, token := range tokens {
+		counts[token]++
+	}
+	score := 0.0
+	for _, term := range terms {
+		if count := counts[term]; count > 0 {
+			score += weight * (0.5*float64(count)/float64(len(tokens)) + 0.5)
+		}
+	}
+	return score
+}
+
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := fields[:0]
+	for _, field := range fields {
+		if !stopWords[field] {
+			tokens = append(tokens, ingredient.Singular(field))
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/search"
+)
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"tomato", "soup", "basil"}, search.Terms("Tomatoes soup with the basil, tomato!"))
+	assert.Empty(t, search.Terms("the and of"))
+	// Terms fold plurals the way ingredient names do.
+	assert.Equal(t, []string{"glass", "berry", "peach"}, search.Terms("glasses berries peaches"))
+}
+
+func TestRecipes_RanksByWeightedFields(t *testing.T) {
+	recipes := []entity.Recipe{
+		{ID: "b", Name: "Caprese Salad", Description: "Fresh tomatoes and mozzarella"},
+		{ID: "a", Name: "Tomato Soup", Description: "A warming soup"},
+		{ID: "c", Name: "Bruschetta", Ingredients: []entity.RecipeIngredient{{Name: "bread"}, {Name: "tomatoes"}}},
+		{ID: "d", Name: "Pancakes", Description: "Fluffy"},
+	}
+
+	hits := search.Recipes(recipes, "tomato")
+
+	if assert.Len(t, hits, 3) {
+		assert.Equal(t, "a", hits[0].Recipe.ID)
+		assert.Equal(t, "c", hits[1].Recipe.ID)
+		assert.Equal(t, "b", hits[2].Recipe.ID)
+		assert.Greater(t, hits[0].Score, hits[1].Score)
+		assert.Greater(t, hits[1].Score, hits[2].Score)
+	}
+}
+
+func TestRecipes_TiesOrderedByID(t *testing.T) {
+	recipes := []entity.Recipe{
+		{ID: "2", Name: "Lemon Tart"},
+		{ID: "1", Name: "Lemon Cake"},
+	}
+
+	hits := search.Recipes(recipes, "lemon")
+
+	if assert.Len(t, hits, 2) {
+		assert.Equal(t, "1", hits[0].Recipe.ID)
+		assert.Equal(t, "2", hits[1].Recipe.ID)
+	}
+}
@@ -0,0 +1,97 @@
+package shelflife
+
+import (
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// catalog holds typical shelf lives keyed by normalized ingredient name.
+// Values are conservative pantry/fridge estimates used only when the client
+// does not supply an expiration.
+var catalog = map[string]time.Duration{
+	// Dairy and eggs
+	"milk":       7 * day,
+	"buttermilk": 14 * day,
+	"cream":      10 * day,
+	"butter":     60 * day,
+	"margarine":  120 * day,
+	"cheese":     30 * day,
+	"parmesan":   180 * day,
+	"yogurt":     14 * day,
+	"egg":        28 * day,
+
+	// Meat and fish
+	"chicken":   2 * day,
+	"beef":      3 * day,
+	"pork":      3 * day,
+	"bacon":     7 * day,
+	"fish":      2 * day,
+	"anchovies": 365 * day,
+
+	// Produce
+	"apple":           30 * day,
+	"banana":          5 * day,
+	"lemon":           21 * day,
+	"onion":           30 * day,
+	"garlic":          90 * day,
+	"ginger":          21 * day,
+	"potato":          60 * day,
+	"carrot":          21 * day,
+	"tomato":          7 * day,
+	"romaine lettuce": 7 * day,
+	"lettuce":         7 * day,
+
+	// Bakery
+	"bread":    5 * day,
+	"croutons": 180 * day,
+
+	// Dry goods
+	"flour":        365 * day,
+	"sugar":        730 * day,
+	"salt":         1825 * day,
+	"rice":         730 * day,
+	"pasta":        730 * day,
+	"oats":         365 * day,
+	"black pepper": 1095 * day,
+	"cinnamon":     1095 * day,
+	"curry powder": 1095 * day,
+
+	// Oils and canned goods
+	"olive oil":    540 * day,
+	"coconut milk": 730 * day,
+}
+
+// Lookup returns the default shelf life for an ingredient name. Names are
+// matched case-insensitively, with underscores treated as spaces and a
+// trailing plural "s" ignored.
+func Lookup(name string) (time.Duration, bool) {
+	key := normalize(name)
+	if d, ok := catalog[key]; ok {
+		return d, true
+	}
+	if singular := strings.TrimSuffix(key, "s"); singular != key {
+		if d, ok := catalog[singular]; ok {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// DefaultExpiration returns the expiration for an ingredient acquired at the
+// given time, or nil when the catalog has no entry for it.
+func DefaultExpiration(name string, acquired time.Time) *time.Time {
+	d, ok := Lookup(name)
+	if !ok {
+		return nil
+	}
+	expiration := acquired.Add(d)
+	return &expiration
+}
+
+func normalize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, "_", " ")
+	return strings.Join(strings.Fields(name), " ")
+}
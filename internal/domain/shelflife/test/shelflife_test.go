@@ -0,0 +1,39 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/shelflife"
+)
+
+func TestLookup(t *testing.T) {
+	tests := map[string]time.Duration{
+		"Milk":             7 * 24 * time.Hour,
+		"  EGGS ":          28 * 24 * time.Hour,
+		"black_pepper":     1095 * 24 * time.Hour,
+		"Romaine  Lettuce": 7 * 24 * time.Hour,
+	}
+
+	for name, expected := range tests {
+		d, ok := shelflife.Lookup(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, expected, d, name)
+	}
+
+	_, ok := shelflife.Lookup("mystery box")
+	assert.False(t, ok)
+}
+
+func TestDefaultExpiration(t *testing.T) {
+	acquired := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	expiration := shelflife.DefaultExpiration("chicken", acquired)
+
+	if assert.NotNil(t, expiration) {
+		assert.Equal(t, time.Date(2024, 3, 3, 9, 0, 0, 0, time.UTC), *expiration)
+	}
+	assert.Nil(t, shelflife.DefaultExpiration("mystery box", acquired))
+}
@@ -0,0 +1,52 @@
+package units
+
+import "strings"
+
+// densities holds approximate densities in grams per millilitre, keyed by
+// normalized ingredient name. Dry goods use their loosely spooned density.
+var densities = map[string]float64{
+	"water":          1.0,
+	"milk":           1.03,
+	"buttermilk":     1.03,
+	"cream":          1.01,
+	"coconut milk":   0.97,
+	"yogurt":         1.03,
+	"butter":         0.911,
+	"margarine":      0.92,
+	"oil":            0.92,
+	"olive oil":      0.91,
+	"vegetable oil":  0.92,
+	"honey":          1.42,
+	"maple syrup":    1.32,
+	"flour":          0.53,
+	"sugar":          0.85,
+	"brown sugar":    0.83,
+	"powdered sugar": 0.56,
+	"salt":           1.2,
+	"black pepper":   0.5,
+	"cinnamon":       0.56,
+	"curry powder":   0.45,
+	"baking soda":    0.96,
+	"baking powder":  0.9,
+	"cocoa powder":   0.42,
+	"rice":           0.85,
+	"oats":           0.41,
+	"parmesan":       0.42,
+	"cheese":         0.45,
+	"lemon juice":    1.03,
+	"vinegar":        1.01,
+}
+
+// Density returns the density of an ingredient in grams per millilitre.
+func Density(ingredient string) (float64, bool) {
+	key := strings.ToLower(strings.TrimSpace(ingredient))
+	key = strings.Join(strings.Fields(strings.ReplaceAll(key, "_", " ")), " ")
+	if d, ok := densities[key]; ok {
+		return d, true
+	}
+	if singular := strings.TrimSuffix(key, "s"); singular != key {
+		d, ok := densities[singular]
+		return d, ok
+	}
+	return 0, false
+}
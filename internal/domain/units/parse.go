@@ -0,0 +1,172 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidQuantity = errors.New("invalid quantity")
+
+var vulgarFractions = map[rune]float64{
+	'¼': 0.25, '½': 0.5, '¾': 0.75,
+	'⅓': 1.0 / 3, '⅔': 2.0 / 3,
+	'⅛': 0.125, '⅜': 0.375, '⅝': 0.625, '⅞': 0.875,
+}
+
+// Parse reads a quantity such as "2 cups", "500g", "1/2 cup", "1 1/2 tbsp",
+// "½ tsp" or "4 slices". A bare number is a count of pieces.
+func Parse(s string) (Quantity, error) {
+	amountText, unitText := splitAmount(strings.TrimSpace(s))
+	if amountText == "" {
+		return Quantity{}, fmt.Errorf("%w: %q", ErrInvalidQuantity, s)
+	}
+	amount, err := parseAmount(amountText)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("%w: %q", ErrInvalidQuantity, s)
+	}
+	return Quantity{Amount: amount, Unit: Resolve(unitText)}, nil
+}
+
+// ParseValue reads a quantity stored as a loosely typed value, as recipe
+// ingredients are: numbers are counts and strings go through Parse.
+func ParseValue(v interface{}) (Quantity, error) {
+	switch val := v.(type) {
+	case int:
+		return Quantity{Amount: float64(val), Unit: Piece}, nil
+	case int32:
+		return Quantity{Amount: float64(val), Unit: Piece}, nil
+	case int64:
+		return Quantity{Amount: float64(val), Unit: Piece}, nil
+	case float64:
+		return Quantity{Amount: val, Unit: Piece}, nil
+	case string:
+		return Parse(val)
+	default:
+		return Quantity{}, fmt.Errorf("%w: unsupported value %v", ErrInvalidQuantity, v)
+	}
+}
+
+// Cut reads the quantity at the start of an ingredient line such as
+// "2 cups flour", "1½ tbsp olive oil" or "1-2 cloves garlic" and returns the
+// text after it. A range counts as its lower bound. The word after the amount
+// is only taken as the unit when it is a known unit; otherwise the quantity
+// is a count and the word stays in rest. ok is false when the line does not
+// start with a positive amount.
+func Cut(line string) (q Quantity, rest string, ok bool) {
+	amountText, rest := splitAmount(strings.TrimSpace(line))
+	amount, err := parseAmount(amountText)
+	if amountText == "" || err != nil || amount <= 0 {
+		return Quantity{}, line, false
+	}
+	if upper, after, found := strings.Cut(rest, "-"); found && upper == "" {
+		if upperText, remainder := splitAmount(strings.TrimSpace(after)); upperText != "" {
+			rest = remainder
+		}
+	}
+
+	q = Quantity{Amount: amount, Unit: Piece}
+	words := strings.Fields(rest)
+	for n := min(2, len(words)); n > 0; n-- {
+		if u, found := Lookup(strings.Join(words[:n], " ")); found {
+			q.Unit = u
+			words = words[n:]
+			break
+		}
+	}
+	return q, strings.Join(words, " "), true
+}
+
+// splitAmount separates the leading numeric part ("1 1/2", "500", "½") from
+// the unit text that follows it.
+func splitAmount(s string) (string, string) {
+	end := 0
+	for i, r := range s {
+		if unicode.IsDigit(r) || r == '.' || r == '/' || r == ' ' || r == ',' {
+			end = i + len(string(r))
+			continue
+		}
+		if _, ok := vulgarFractions[r]; ok {
+			end = i + len(string(r))
+			continue
+		}
+		break
+	}
+	return strings.TrimSpace(s[:end]), strings.TrimSpace(s[end:])
+}
+
+func parseAmount(s string) (float64, error) {
+	total := 0.0
+	for _, field := range strings.Fields(s) {
+		value, err := parseNumber(field)
+		if err != nil {
+			return 0, err
+		}
+		total += value
+	}
+	if math.IsNaN(total) || math.IsInf(total, 0) {
+		return 0, ErrInvalidQuantity
+	}
+	return total, nil
+}
+
+func parseNumber(s string) (float64, error) {
+	// A trailing vulgar fraction may be glued to a whole number ("1½").
+	var fraction float64
+	for r, value := range vulgarFractions {
+		if strings.HasSuffix(s, string(r)) {
+			fraction = value
+			s = strings.TrimSuffix(s, string(r))
+			break
+		}
+	}
+	if s == "" {
+		return fraction, nil
+	}
+
+	if numerator, denominator, ok := strings.Cut(s, "/"); ok {
+		n, err := strconv.ParseFloat(numerator, 64)
+		if err != nil {
+			return 0, err
+		}
+		d, err := strconv.ParseFloat(denominator, 64)
+		if err != nil || d == 0 {
+			return 0, ErrInvalidQuantity
+		}
+		return n/d + fraction, nil
+	}
+
+	value, err := strconv.ParseFloat(normalizeCommas(s), 64)
+	if err != nil {
+		return 0, err
+	}
+	return value + fraction, nil
+}
+
+// normalizeCommas prepares a number with commas for strconv. When every
+// comma is followed by exactly three digits they separate thousands
+// ("1,000", "12,500.5") and are dropped; otherwise a comma is a decimal comma
+// ("1,5").
+func normalizeCommas(s string) string {
+	for i := strings.IndexByte(s, ','); i >= 0; {
+		digits := 0
+		for _, r := range s[i+1:] {
+			if r < '0' || r > '9' {
+				break
+			}
+			digits++
+		}
+		if digits != 3 {
+			return strings.ReplaceAll(s, ",", ".")
+		}
+		next := strings.IndexByte(s[i+1:], ',')
+		if next < 0 {
+			break
+		}
+		i += 1 + next
+	}
+	return strings.ReplaceAll(s, ",", "")
+}
@@ -0,0 +1,112 @@
+package units
+
+import "math"
+
+// rung is one step of a unit ladder: the unit preferred from a given amount
+// in base units upwards, and how amounts in it are rounded.
+type rung struct {
+	unit  Unit
+	from  float64
+	round func(float64) float64
+}
+
+// Ladders keep scaled quantities within the measuring system they started
+// in, moving to the next unit up once the amount fills it comfortably.
+var (
+	usVolume = []rung{
+		{Teaspoon, 0, toNearest(0.125)},
+		{Tablespoon, 3 * Teaspoon.Factor, toNearest(0.5)},
+		{Cup, Cup.Factor / 4, toNearest(0.125)},
+		{Quart, Quart.Factor, toNearest(0.25)},
+		{Gallon, Gallon.Factor, toNearest(0.25)},
+	}
+	metricVolume = []rung{
+		{Millilitre, 0, toMagnitude},
+		{Litre, Litre.Factor, toNearest(0.1)},
+	}
+	metricMass = []rung{
+		{Milligram, 0, toMagnitude},
+		{Gram, Gram.Factor, toMagnitude},
+		{Kilogram, Kilogram.Factor, toNearest(0.1)},
+	}
+	imperialMass = []rung{
+		{Ounce, 0, toNearest(0.5)},
+		{Pound, Pound.Factor, toNearest(0.25)},
+	}
+	count = []rung{
+		{Piece, 0, toNearest(1)},
+	}
+)
+
+// Scale multiplies a quantity by factor and tidies the result for a cook:
+// the amount is rounded to a step that can be measured, counts stay whole,
+// and the unit moves up or down its ladder when the amount outgrows it, so
+// 48 tsp becomes 1 cup. A positive amount never rounds down to zero.
+func Scale(q Quantity, factor float64) Quantity {
+	amount := q.Amount * factor
+	if amount <= 0 {
+		return Quantity{Amount: amount, Unit: q.Unit}
+	}
+	ladder := ladderFor(q.Unit)
+	if ladder == nil {
+		// Units such as "slice" or "head" are only rounded to halves.
+		return Quantity{Amount: tidy(toNearest(0.5)(amount)), Unit: q.Unit}
+	}
+
+	base := amount * q.Unit.Factor
+	chosen := ladder[0]
+	for _, candidate := range ladder[1:] {
+		if base >= candidate.from {
+			chosen = candidate
+		}
+	}
+	return Quantity{Amount: tidy(chosen.round(base / chosen.unit.Factor)), Unit: chosen.unit}
+}
+
+func ladderFor(u Unit) []rung {
+	switch u {
+	case Teaspoon, Tablespoon, FluidOunce, Cup, Pint, Quart, Gallon:
+		return usVolume
+	case Millilitre, Centilitre, Decilitre, Litre:
+		return metricVolume
+	case Milligram, Gram, Kilogram:
+		return metricMass
+	case Ounce, Pound:
+		return imperialMass
+	case Piece, Dozen:
+		return count
+	}
+	return nil
+}
+
+// toNearest rounds to a multiple of step. A positive amount that would round
+// to zero becomes one step instead.
+func toNearest(step float64) func(float64) float64 {
+	return func(v float64) float64 {
+		rounded := math.Round(v/step) * step
+		if rounded == 0 && v > 0 {
+			return step
+		}
+		return rounded
+	}
+}
+
+// toMagnitude rounds metric amounts more coarsely as they grow: to 0.1 below
+// 1, to 1 below 10, to 5 below 100 and to 10 above.
+func toMagnitude(v float64) float64 {
+	switch {
+	case v < 1:
+		return toNearest(0.1)(v)
+	case v < 10:
+		return toNearest(1)(v)
+	case v < 100:
+		return toNearest(5)(v)
+	default:
+		return toNearest(10)(v)
+	}
+}
+
+// tidy removes floating point residue left by rounding to decimal steps.
+func tidy(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,170 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/units"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input  string
+		amount float64
+		unit   units.Unit
+	}{
+		{"2 cups", 2, units.Cup},
+		{"500g", 500, units.Gram},
+		{"1/2 cup", 0.5, units.Cup},
+		{"1 1/2 tbsp", 1.5, units.Tablespoon},
+		{"½ tsp", 0.5, units.Teaspoon},
+		{"1½ Tbsp.", 1.5, units.Tablespoon},
+		{"400ml", 400, units.Millilitre},
+		{"2 fl oz", 2, units.FluidOunce},
+		{"5", 5, units.Piece},
+		{"1,5 kg", 1.5, units.Kilogram},
+		{"0,25 l", 0.25, units.Litre},
+		{"1,000 g", 1000, units.Gram},
+		{"12,500.5 g", 12500.5, units.Gram},
+		{"1,000,000 ml", 1000000, units.Millilitre},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := units.Parse(tt.input)
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.amount, q.Amount, 1e-9)
+			assert.Equal(t, tt.unit, q.Unit)
+		})
+	}
+}
+
+func TestParse_UnknownUnitIsOther(t *testing.T) {
+	q, err := units.Parse("4 slices")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, q.Amount)
+	assert.Equal(t, units.Other, q.Unit.Dimension)
+	assert.True(t, units.Compatible(q.Unit, units.Resolve("slice")))
+	assert.False(t, units.Compatible(q.Unit, units.Resolve("cloves")))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"", "cups", "1/0 cup"} {
+		_, err := units.Parse(input)
+		assert.ErrorIs(t, err, units.ErrInvalidQuantity, input)
+	}
+}
+
+func TestCut(t *testing.T) {
+	tests := []struct {
+		line   string
+		amount float64
+		unit   units.Unit
+		rest   string
+	}{
+		{"2 cups all-purpose flour", 2, units.Cup, "all-purpose flour"},
+		{"1½ Tbsp. olive oil", 1.5, units.Tablespoon, "olive oil"},
+		{"2 fl oz cream", 2, units.FluidOunce, "cream"},
+		{"1-2 cloves garlic", 1, units.Piece, "cloves garlic"},
+		{"3 eggs", 3, units.Piece, "eggs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			q, rest, ok := units.Cut(tt.line)
+
+			assert.True(t, ok)
+			assert.InDelta(t, tt.amount, q.Amount, 1e-9)
+			assert.Equal(t, tt.unit, q.Unit)
+			assert.Equal(t, tt.rest, rest)
+		})
+	}
+
+	_, rest, ok := units.Cut("salt to taste")
+	assert.False(t, ok)
+	assert.Equal(t, "salt to taste", rest)
+}
+
+func TestParseValue(t *testing.T) {
+	q, err := units.ParseValue(int32(5))
+	assert.NoError(t, err)
+	assert.Equal(t, units.Quantity{Amount: 5, Unit: units.Piece}, q)
+
+	q, err = units.ParseValue("1 tsp")
+	assert.NoError(t, err)
+	assert.Equal(t, units.Teaspoon, q.Unit)
+}
+
+func TestConvert_SameDimension(t *testing.T) {
+	q, err := units.Convert(units.Quantity{Amount: 2, Unit: units.Kilogram}, units.Gram, "")
+	assert.NoError(t, err)
+	assert.InDelta(t, 2000, q.Amount, 1e-9)
+
+	q, err = units.Convert(units.Quantity{Amount: 48, Unit: units.Teaspoon}, units.Cup, "")
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, q.Amount, 1e-9)
+}
+
+func TestConvert_VolumeToMassUsesDensity(t *testing.T) {
+	q, err := units.Convert(units.Quantity{Amount: 1, Unit: units.Cup}, units.Gram, "Flour")
+	assert.NoError(t, err)
+	assert.InDelta(t, 125.4, q.Amount, 0.1)
+
+	q, err = units.Convert(units.Quantity{Amount: 1, Unit: units.Kilogram}, units.Litre, "sugar")
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.176, q.Amount, 0.001)
+}
+
+func TestConvert_Errors(t *testing.T) {
+	_, err := units.Convert(units.Quantity{Amount: 1, Unit: units.Cup}, units.Gram, "mystery powder")
+	assert.ErrorIs(t, err, units.ErrNoDensity)
+
+	_, err = units.Convert(units.Quantity{Amount: 1, Unit: units.Piece}, units.Gram, "flour")
+	assert.ErrorIs(t, err, units.ErrIncompatible)
+}
+
+func TestConvertAmount(t *testing.T) {
+	amount, err := units.ConvertAmount(500, "g", "kg", "")
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.5, amount, 1e-9)
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  units.Quantity
+		factor float64
+		amount float64
+		unit   units.Unit
+	}{
+		{"teaspoons become a cup", units.Quantity{Amount: 12, Unit: units.Teaspoon}, 4, 1, units.Cup},
+		{"cups become tablespoons", units.Quantity{Amount: 0.5, Unit: units.Cup}, 0.25, 2, units.Tablespoon},
+		{"teaspoons round to eighths", units.Quantity{Amount: 1, Unit: units.Teaspoon}, 0.33, 0.375, units.Teaspoon},
+		{"no fractional eggs", units.Quantity{Amount: 3, Unit: units.Piece}, 0.125, 1, units.Piece},
+		{"counts round to whole", units.Quantity{Amount: 3, Unit: units.Piece}, 1.5, 5, units.Piece},
+		{"grams become kilograms", units.Quantity{Amount: 500, Unit: units.Gram}, 3, 1.5, units.Kilogram},
+		{"grams round by magnitude", units.Quantity{Amount: 200, Unit: units.Gram}, 0.333, 65, units.Gram},
+		{"litres become millilitres", units.Quantity{Amount: 1, Unit: units.Litre}, 0.25, 250, units.Millilitre},
+		{"ounces become pounds", units.Quantity{Amount: 8, Unit: units.Ounce}, 3, 1.5, units.Pound},
+		{"milligrams stay milligrams", units.Quantity{Amount: 250, Unit: units.Milligram}, 1, 250, units.Milligram},
+		{"fractional grams become milligrams", units.Quantity{Amount: 0.5, Unit: units.Gram}, 1, 500, units.Milligram},
+		{"fluid ounces keep their volume", units.Quantity{Amount: 3, Unit: units.FluidOunce}, 1, 0.375, units.Cup},
+		{"gallons stay gallons", units.Quantity{Amount: 1, Unit: units.Gallon}, 1, 1, units.Gallon},
+		{"gallons scale up", units.Quantity{Amount: 1, Unit: units.Gallon}, 4, 4, units.Gallon},
+		{"cups become quarts", units.Quantity{Amount: 3, Unit: units.Cup}, 2, 1.5, units.Quart},
+		{"other units round to halves", units.Quantity{Amount: 1, Unit: units.Resolve("head")}, 0.5, 0.5, units.Resolve("head")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := units.Scale(tt.input, tt.factor)
+
+			assert.InDelta(t, tt.amount, q.Amount, 1e-9)
+			assert.Equal(t, tt.unit, q.Unit)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Dimension is the physical quantity a unit measures. Only units of the same
+// dimension convert directly; mass and volume convert through an ingredient
+// density.
+type Dimension int
+
+const (
+	// Other covers units that only compare to themselves, such as "slice"
+	// or "clove".
+	Other Dimension = iota
+	Mass
+	Volume
+	Count
+)
+
+func (d Dimension) String() string {
+	switch d {
+	case Mass:
+		return "mass"
+	case Volume:
+		return "volume"
+	case Count:
+		return "count"
+	default:
+		return "other"
+	}
+}
+
+// Unit is a unit of measure. Factor converts one of the unit into the base
+// unit of its dimension: grams for mass, millilitres for volume and pieces
+// for count.
+type Unit struct {
+	Symbol    string
+	Dimension Dimension
+	Factor    float64
+}
+
+var (
+	ErrIncompatible = errors.New("incompatible units")
+	ErrNoDensity    = errors.New("no density known for ingredient")
+)
+
+var (
+	Milligram  = Unit{"mg", Mass, 0.001}
+	Gram       = Unit{"g", Mass, 1}
+	Kilogram   = Unit{"kg", Mass, 1000}
+	Ounce      = Unit{"oz", Mass, 28.349523125}
+	Pound      = Unit{"lb", Mass, 453.59237}
+	Millilitre = Unit{"ml", Volume, 1}
+	Centilitre = Unit{"cl", Volume, 10}
+	Decilitre  = Unit{"dl", Volume, 100}
+	Litre      = Unit{"l", Volume, 1000}
+	Teaspoon   = Unit{"tsp", Volume, 4.92892159375}
+	Tablespoon = Unit{"tbsp", Volume, 14.78676478125}
+	FluidOunce = Unit{"fl oz", Volume, 29.5735295625}
+	Cup        = Unit{"cup", Volume, 236.5882365}
+	Pint       = Unit{"pt", Volume, 473.176473}
+	Quart      = Unit{"qt", Volume, 946.352946}
+	Gallon     = Unit{"gal", Volume, 3785.411784}
+	Piece      = Unit{"pc", Count, 1}
+	Dozen      = Unit{"dozen", Count, 12}
+)
+
+// aliases maps every accepted spelling to its unit.
+var aliases = map[string]Unit{
+	"mg": Milligram, "milligram": Milligram, "milligrams": Milligram,
+	"g": Gram, "gr": Gram, "gram": Gram, "grams": Gram, "gramme": Gram, "grammes": Gram,
+	"kg": Kilogram, "kgs": Kilogram, "kilo": Kilogram, "kilos": Kilogram, "kilogram": Kilogram, "kilograms": Kilogram,
+	"oz": Ounce, "ounce": Ounce, "ounces": Ounce,
+	"lb": Pound, "lbs": Pound, "pound": Pound, "pounds": Pound,
+	"ml": Millilitre, "millilitre": Millilitre, "millilitres": Millilitre, "milliliter": Millilitre, "milliliters": Millilitre,
+	"cl": Centilitre, "centilitre": Centilitre, "centilitres": Centilitre, "centiliter": Centilitre, "centiliters": Centilitre,
+	"dl": Decilitre, "decilitre": Decilitre, "decilitres": Decilitre, "deciliter": Decilitre, "deciliters": Decilitre,
+	"l": Litre, "litre": Litre, "litres": Litre, "liter": Litre, "liters": Litre,
+	"tsp": Teaspoon, "tsps": Teaspoon, "teaspoon": Teaspoon, "teaspoons": Teaspoon,
+	"tbsp": Tablespoon, "tbsps": Tablespoon, "tbs": Tablespoon, "tablespoon": Tablespoon, "tablespoons": Tablespoon,
+	"fl oz": FluidOunce, "floz": FluidOunce, "fluid ounce": FluidOunce, "fluid ounces": FluidOunce,
+	"cup": Cup, "cups": Cup, "c": Cup,
+	"pt": Pint, "pint": Pint, "pints": Pint,
+	"qt": Quart, "quart": Quart, "quarts": Quart,
+	"gal": Gallon, "gallon": Gallon, "gallons": Gallon,
+	"": Piece, "pc": Piece, "pcs": Piece, "piece": Piece, "pieces": Piece, "each": Piece, "ea": Piece, "whole": Piece, "unit": Piece, "units": Piece,
+	"dozen": Dozen, "doz": Dozen,
+}
+
+// Lookup resolves a unit name or abbreviation. Names are matched
+// case-insensitively and a trailing period is ignored ("Tbsp." is "tbsp").
+func Lookup(name string) (Unit, bool) {
+	key := normalizeUnit(name)
+	u, ok := aliases[key]
+	return u, ok
+}
+
+// Resolve is like Lookup but treats an unrecognized name as a unit of
+// dimension Other, so that quantities like "4 slices" can still be compared
+// with other quantities in slices.
+func Resolve(name string) Unit {
+	if u, ok := Lookup(name); ok {
+		return u
+	}
+	symbol := normalizeUnit(name)
+	if strings.HasSuffix(symbol, "s") && !strings.HasSuffix(symbol, "ss") {
+		symbol = strings.TrimSuffix(symbol, "s")
+	}
+	return Unit{Symbol: symbol, Dimension: Other, Factor: 1}
+}
+
+// Compatible reports whether quantities in a and b can be converted into each
+// other without knowing the ingredient.
+func Compatible(a, b Unit) bool {
+	if a.Dimension != b.Dimension {
+		return false
+	}
+	return a.Dimension != Other || a.Symbol == b.Symbol
+}
+
+// Quantity is an amount expressed in a unit.
+type Quantity struct {
+	Amount float64
+	Unit   Unit
+}
+
+func (q Quantity) String() string {
+	amount := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.3f", q.Amount), "0"), ".")
+	if q.Unit.Symbol == "" || q.Unit == Piece {
+		return amount
+	}
+	return amount + " " + q.Unit.Symbol
+}
+
+// Convert expresses q in the target unit. Mass and volume convert into each
+// other using the density of the named ingredient; any other mix of
+// dimensions is rejected with ErrIncompatible.
+func Convert(q Quantity, to Unit, ingredient string) (Quantity, error) {
+	from := q.Unit
+	if Compatible(from, to) {
+		return Quantity{Amount: q.Amount * from.Factor / to.Factor, Unit: to}, nil
+	}
+
+	switch {
+	case from.Dimension == Volume && to.Dimension == Mass:
+		density, ok := Density(ingredient)
+		if !ok {
+			return Quantity{}, fmt.Errorf("%w: %q", ErrNoDensity, ingredient)
+		}
+		grams := q.Amount * from.Factor * density
+		return Quantity{Amount: grams / to.Factor, Unit: to}, nil
+	case from.Dimension == Mass && to.Dimension == Volume:
+		density, ok := Density(ingredient)
+		if !ok {
+			return Quantity{}, fmt.Errorf("%w: %q", ErrNoDensity, ingredient)
+		}
+		millilitres := q.Amount * from.Factor / density
+		return Quantity{Amount: millilitres / to.Factor, Unit: to}, nil
+	}
+	return Quantity{}, fmt.Errorf("%w: %s to %s", ErrIncompatible, from.Symbol, to.Symbol)
+}
+
+// ConvertAmount converts an amount between two unit names, for callers that
+// store units as free-form strings such as PantryEntry.QuantityType.
+func ConvertAmount(amount float64, from string, to string, ingredient string) (float64, error) {
+	q, err := Convert(Quantity{Amount: amount, Unit: Resolve(from)}, Resolve(to), ingredient)
+	if err != nil {
+		return 0, err
+	}
+	return q.Amount, nil
+}
+
+func normalizeUnit(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.TrimSuffix(name, ".")
+	return strings.Join(strings.Fields(name), " ")
+}
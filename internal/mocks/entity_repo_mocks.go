@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/thisausername99/pantry_butler/internal/domain/repository (interfaces: PantryRepository,RecipeRepository,UserRepository)
+// Source: github.com/thisausername99/pantry_butler/internal/domain/repository (interfaces: PantryRepository,RecipeRepository,UserRepository,InviteRepository,ProductRepository,ShoppingListRepository,MealPlanRepository,HistoryRepository,RatingRepository,SubstitutionRepository,Transactor)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	entity "github.com/thisausername99/pantry_butler/internal/domain/entity"
@@ -35,6 +36,49 @@ func (m *MockPantryRepository) EXPECT() *MockPantryRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddPantryMember mocks base method.
+func (m *MockPantryRepository) AddPantryMember(arg0 context.Context, arg1 string, arg2 *entity.PantryMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPantryMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPantryMember indicates an expected call of AddPantryMember.
+func (mr *MockPantryRepositoryMockRecorder) AddPantryMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPantryMember", reflect.TypeOf((*MockPantryRepository)(nil).AddPantryMember), arg0, arg1, arg2)
+}
+
+// AddStorageLocation mocks base method.
+func (m *MockPantryRepository) AddStorageLocation(arg0 context.Context, arg1 string, arg2 *entity.StorageLocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStorageLocation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStorageLocation indicates an expected call of AddStorageLocation.
+func (mr *MockPantryRepositoryMockRecorder) AddStorageLocation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStorageLocation", reflect.TypeOf((*MockPantryRepository)(nil).AddStorageLocation), arg0, arg1, arg2)
+}
+
+// ConsumePantryEntry mocks base method.
+func (m *MockPantryRepository) ConsumePantryEntry(arg0 context.Context, arg1, arg2 string, arg3 float64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePantryEntry", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumePantryEntry indicates an expected call of ConsumePantryEntry.
+func (mr *MockPantryRepositoryMockRecorder) ConsumePantryEntry(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePantryEntry", reflect.TypeOf((*MockPantryRepository)(nil).ConsumePantryEntry), arg0, arg1, arg2, arg3)
+}
+
 // CreateNewPantry mocks base method.
 func (m *MockPantryRepository) CreateNewPantry(arg0 context.Context, arg1 *entity.Pantry) error {
 	m.ctrl.T.Helper()
@@ -77,6 +121,36 @@ func (mr *MockPantryRepositoryMockRecorder) DeletePantryEntry(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePantryEntry", reflect.TypeOf((*MockPantryRepository)(nil).DeletePantryEntry), arg0, arg1, arg2)
 }
 
+// FindPantryEntries mocks base method.
+func (m *MockPantryRepository) FindPantryEntries(arg0 context.Context, arg1 string, arg2 *entity.PantryEntryFilter) ([]entity.PantryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPantryEntries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entity.PantryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPantryEntries indicates an expected call of FindPantryEntries.
+func (mr *MockPantryRepositoryMockRecorder) FindPantryEntries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPantryEntries", reflect.TypeOf((*MockPantryRepository)(nil).FindPantryEntries), arg0, arg1, arg2)
+}
+
+// GetPantry mocks base method.
+func (m *MockPantryRepository) GetPantry(arg0 context.Context, arg1 string) (*entity.Pantry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPantry", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Pantry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPantry indicates an expected call of GetPantry.
+func (mr *MockPantryRepositoryMockRecorder) GetPantry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPantry", reflect.TypeOf((*MockPantryRepository)(nil).GetPantry), arg0, arg1)
+}
+
 // GetPantryEntries mocks base method.
 func (m *MockPantryRepository) GetPantryEntries(arg0 context.Context, arg1 string) ([]entity.PantryEntry, error) {
 	m.ctrl.T.Helper()
@@ -92,6 +166,34 @@ func (mr *MockPantryRepositoryMockRecorder) GetPantryEntries(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPantryEntries", reflect.TypeOf((*MockPantryRepository)(nil).GetPantryEntries), arg0, arg1)
 }
 
+// IncrementPantryEntry mocks base method.
+func (m *MockPantryRepository) IncrementPantryEntry(arg0 context.Context, arg1, arg2 string, arg3 float64, arg4 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementPantryEntry", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementPantryEntry indicates an expected call of IncrementPantryEntry.
+func (mr *MockPantryRepositoryMockRecorder) IncrementPantryEntry(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementPantryEntry", reflect.TypeOf((*MockPantryRepository)(nil).IncrementPantryEntry), arg0, arg1, arg2, arg3, arg4)
+}
+
+// InsertPantryEntries mocks base method.
+func (m *MockPantryRepository) InsertPantryEntries(arg0 context.Context, arg1 string, arg2 []entity.PantryEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPantryEntries", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPantryEntries indicates an expected call of InsertPantryEntries.
+func (mr *MockPantryRepositoryMockRecorder) InsertPantryEntries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPantryEntries", reflect.TypeOf((*MockPantryRepository)(nil).InsertPantryEntries), arg0, arg1, arg2)
+}
+
 // InsertPantryEntry mocks base method.
 func (m *MockPantryRepository) InsertPantryEntry(arg0 context.Context, arg1 string, arg2 *entity.PantryEntry) error {
 	m.ctrl.T.Helper()
@@ -106,6 +208,104 @@ func (mr *MockPantryRepositoryMockRecorder) InsertPantryEntry(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPantryEntry", reflect.TypeOf((*MockPantryRepository)(nil).InsertPantryEntry), arg0, arg1, arg2)
 }
 
+// MergePantryEntries mocks base method.
+func (m *MockPantryRepository) MergePantryEntries(arg0 context.Context, arg1 string, arg2 *entity.PantryEntry, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePantryEntries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergePantryEntries indicates an expected call of MergePantryEntries.
+func (mr *MockPantryRepositoryMockRecorder) MergePantryEntries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePantryEntries", reflect.TypeOf((*MockPantryRepository)(nil).MergePantryEntries), arg0, arg1, arg2, arg3)
+}
+
+// MovePantryEntry mocks base method.
+func (m *MockPantryRepository) MovePantryEntry(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePantryEntry", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MovePantryEntry indicates an expected call of MovePantryEntry.
+func (mr *MockPantryRepositoryMockRecorder) MovePantryEntry(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePantryEntry", reflect.TypeOf((*MockPantryRepository)(nil).MovePantryEntry), arg0, arg1, arg2, arg3)
+}
+
+// RemovePantryMember mocks base method.
+func (m *MockPantryRepository) RemovePantryMember(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePantryMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePantryMember indicates an expected call of RemovePantryMember.
+func (mr *MockPantryRepositoryMockRecorder) RemovePantryMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePantryMember", reflect.TypeOf((*MockPantryRepository)(nil).RemovePantryMember), arg0, arg1, arg2)
+}
+
+// RemoveStorageLocation mocks base method.
+func (m *MockPantryRepository) RemoveStorageLocation(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveStorageLocation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveStorageLocation indicates an expected call of RemoveStorageLocation.
+func (mr *MockPantryRepositoryMockRecorder) RemoveStorageLocation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveStorageLocation", reflect.TypeOf((*MockPantryRepository)(nil).RemoveStorageLocation), arg0, arg1, arg2)
+}
+
+// TagPantryEntry mocks base method.
+func (m *MockPantryRepository) TagPantryEntry(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagPantryEntry", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagPantryEntry indicates an expected call of TagPantryEntry.
+func (mr *MockPantryRepositoryMockRecorder) TagPantryEntry(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagPantryEntry", reflect.TypeOf((*MockPantryRepository)(nil).TagPantryEntry), arg0, arg1, arg2, arg3)
+}
+
+// UntagPantryEntry mocks base method.
+func (m *MockPantryRepository) UntagPantryEntry(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagPantryEntry", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UntagPantryEntry indicates an expected call of UntagPantryEntry.
+func (mr *MockPantryRepositoryMockRecorder) UntagPantryEntry(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagPantryEntry", reflect.TypeOf((*MockPantryRepository)(nil).UntagPantryEntry), arg0, arg1, arg2, arg3)
+}
+
+// UpdatePantryEntry mocks base method.
+func (m *MockPantryRepository) UpdatePantryEntry(arg0 context.Context, arg1, arg2 string, arg3 *entity.PantryEntryPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePantryEntry", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePantryEntry indicates an expected call of UpdatePantryEntry.
+func (mr *MockPantryRepositoryMockRecorder) UpdatePantryEntry(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePantryEntry", reflect.TypeOf((*MockPantryRepository)(nil).UpdatePantryEntry), arg0, arg1, arg2, arg3)
+}
+
 // MockRecipeRepository is a mock of RecipeRepository interface.
 type MockRecipeRepository struct {
 	ctrl     *gomock.Controller
@@ -129,6 +329,63 @@ func (m *MockRecipeRepository) EXPECT() *MockRecipeRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AdjustRecipeRating mocks base method.
+func (m *MockRecipeRepository) AdjustRecipeRating(arg0 context.Context, arg1 string, arg2, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustRecipeRating", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustRecipeRating indicates an expected call of AdjustRecipeRating.
+func (mr *MockRecipeRepositoryMockRecorder) AdjustRecipeRating(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustRecipeRating", reflect.TypeOf((*MockRecipeRepository)(nil).AdjustRecipeRating), arg0, arg1, arg2, arg3)
+}
+
+// CreateRecipe mocks base method.
+func (m *MockRecipeRepository) CreateRecipe(arg0 context.Context, arg1 *entity.Recipe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRecipe indicates an expected call of CreateRecipe.
+func (mr *MockRecipeRepositoryMockRecorder) CreateRecipe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipe", reflect.TypeOf((*MockRecipeRepository)(nil).CreateRecipe), arg0, arg1)
+}
+
+// DeleteRecipe mocks base method.
+func (m *MockRecipeRepository) DeleteRecipe(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipe indicates an expected call of DeleteRecipe.
+func (mr *MockRecipeRepositoryMockRecorder) DeleteRecipe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipe", reflect.TypeOf((*MockRecipeRepository)(nil).DeleteRecipe), arg0, arg1)
+}
+
+// GetRecipe mocks base method.
+func (m *MockRecipeRepository) GetRecipe(arg0 context.Context, arg1 string) (*entity.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipe", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipe indicates an expected call of GetRecipe.
+func (mr *MockRecipeRepositoryMockRecorder) GetRecipe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipe", reflect.TypeOf((*MockRecipeRepository)(nil).GetRecipe), arg0, arg1)
+}
+
 // GetRecipes mocks base method.
 func (m *MockRecipeRepository) GetRecipes(arg0 context.Context) ([]entity.Recipe, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +416,50 @@ func (mr *MockRecipeRepositoryMockRecorder) GetRecipesByCuisine(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipesByCuisine", reflect.TypeOf((*MockRecipeRepository)(nil).GetRecipesByCuisine), arg0, arg1)
 }
 
+// GetRecipesByIDs mocks base method.
+func (m *MockRecipeRepository) GetRecipesByIDs(arg0 context.Context, arg1 []string) ([]entity.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipesByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]entity.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipesByIDs indicates an expected call of GetRecipesByIDs.
+func (mr *MockRecipeRepositoryMockRecorder) GetRecipesByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipesByIDs", reflect.TypeOf((*MockRecipeRepository)(nil).GetRecipesByIDs), arg0, arg1)
+}
+
+// SearchRecipes mocks base method.
+func (m *MockRecipeRepository) SearchRecipes(arg0 context.Context, arg1 string, arg2, arg3 int) ([]entity.RecipeSearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchRecipes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]entity.RecipeSearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchRecipes indicates an expected call of SearchRecipes.
+func (mr *MockRecipeRepositoryMockRecorder) SearchRecipes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRecipes", reflect.TypeOf((*MockRecipeRepository)(nil).SearchRecipes), arg0, arg1, arg2, arg3)
+}
+
+// UpdateRecipe mocks base method.
+func (m *MockRecipeRepository) UpdateRecipe(arg0 context.Context, arg1 *entity.Recipe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecipe indicates an expected call of UpdateRecipe.
+func (mr *MockRecipeRepositoryMockRecorder) UpdateRecipe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipe", reflect.TypeOf((*MockRecipeRepository)(nil).UpdateRecipe), arg0, arg1)
+}
+
 // MockUserRepository is a mock of UserRepository interface.
 type MockUserRepository struct {
 	ctrl     *gomock.Controller
@@ -253,3 +554,506 @@ func (mr *MockUserRepositoryMockRecorder) UpdateUserWithPantry(arg0, arg1, arg2
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserWithPantry", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserWithPantry), arg0, arg1, arg2)
 }
+
+// MockInviteRepository is a mock of InviteRepository interface.
+type MockInviteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInviteRepositoryMockRecorder
+}
+
+// MockInviteRepositoryMockRecorder is the mock recorder for MockInviteRepository.
+type MockInviteRepositoryMockRecorder struct {
+	mock *MockInviteRepository
+}
+
+// NewMockInviteRepository creates a new mock instance.
+func NewMockInviteRepository(ctrl *gomock.Controller) *MockInviteRepository {
+	mock := &MockInviteRepository{ctrl: ctrl}
+	mock.recorder = &MockInviteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInviteRepository) EXPECT() *MockInviteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvite mocks base method.
+func (m *MockInviteRepository) AcceptInvite(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvite", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvite indicates an expected call of AcceptInvite.
+func (mr *MockInviteRepositoryMockRecorder) AcceptInvite(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvite", reflect.TypeOf((*MockInviteRepository)(nil).AcceptInvite), arg0, arg1, arg2, arg3)
+}
+
+// CreateInvite mocks base method.
+func (m *MockInviteRepository) CreateInvite(arg0 context.Context, arg1 *entity.PantryInvite) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvite", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvite indicates an expected call of CreateInvite.
+func (mr *MockInviteRepositoryMockRecorder) CreateInvite(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvite", reflect.TypeOf((*MockInviteRepository)(nil).CreateInvite), arg0, arg1)
+}
+
+// GetInvite mocks base method.
+func (m *MockInviteRepository) GetInvite(arg0 context.Context, arg1 string) (*entity.PantryInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvite", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PantryInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvite indicates an expected call of GetInvite.
+func (mr *MockInviteRepositoryMockRecorder) GetInvite(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvite", reflect.TypeOf((*MockInviteRepository)(nil).GetInvite), arg0, arg1)
+}
+
+// MockProductRepository is a mock of ProductRepository interface.
+type MockProductRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductRepositoryMockRecorder
+}
+
+// MockProductRepositoryMockRecorder is the mock recorder for MockProductRepository.
+type MockProductRepositoryMockRecorder struct {
+	mock *MockProductRepository
+}
+
+// NewMockProductRepository creates a new mock instance.
+func NewMockProductRepository(ctrl *gomock.Controller) *MockProductRepository {
+	mock := &MockProductRepository{ctrl: ctrl}
+	mock.recorder = &MockProductRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetProduct mocks base method.
+func (m *MockProductRepository) GetProduct(arg0 context.Context, arg1 string) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockProductRepositoryMockRecorder) GetProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockProductRepository)(nil).GetProduct), arg0, arg1)
+}
+
+// UpsertProduct mocks base method.
+func (m *MockProductRepository) UpsertProduct(arg0 context.Context, arg1 *entity.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProduct", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertProduct indicates an expected call of UpsertProduct.
+func (mr *MockProductRepositoryMockRecorder) UpsertProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProduct", reflect.TypeOf((*MockProductRepository)(nil).UpsertProduct), arg0, arg1)
+}
+
+// MockShoppingListRepository is a mock of ShoppingListRepository interface.
+type MockShoppingListRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShoppingListRepositoryMockRecorder
+}
+
+// MockShoppingListRepositoryMockRecorder is the mock recorder for MockShoppingListRepository.
+type MockShoppingListRepositoryMockRecorder struct {
+	mock *MockShoppingListRepository
+}
+
+// NewMockShoppingListRepository creates a new mock instance.
+func NewMockShoppingListRepository(ctrl *gomock.Controller) *MockShoppingListRepository {
+	mock := &MockShoppingListRepository{ctrl: ctrl}
+	mock.recorder = &MockShoppingListRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShoppingListRepository) EXPECT() *MockShoppingListRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddShoppingListItem mocks base method.
+func (m *MockShoppingListRepository) AddShoppingListItem(arg0 context.Context, arg1 string, arg2 *entity.ShoppingListItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddShoppingListItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddShoppingListItem indicates an expected call of AddShoppingListItem.
+func (mr *MockShoppingListRepositoryMockRecorder) AddShoppingListItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddShoppingListItem", reflect.TypeOf((*MockShoppingListRepository)(nil).AddShoppingListItem), arg0, arg1, arg2)
+}
+
+// ClearShoppingListItems mocks base method.
+func (m *MockShoppingListRepository) ClearShoppingListItems(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearShoppingListItems", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearShoppingListItems indicates an expected call of ClearShoppingListItems.
+func (mr *MockShoppingListRepositoryMockRecorder) ClearShoppingListItems(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearShoppingListItems", reflect.TypeOf((*MockShoppingListRepository)(nil).ClearShoppingListItems), arg0, arg1, arg2)
+}
+
+// CreateShoppingList mocks base method.
+func (m *MockShoppingListRepository) CreateShoppingList(arg0 context.Context, arg1 *entity.ShoppingList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShoppingList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShoppingList indicates an expected call of CreateShoppingList.
+func (mr *MockShoppingListRepositoryMockRecorder) CreateShoppingList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShoppingList", reflect.TypeOf((*MockShoppingListRepository)(nil).CreateShoppingList), arg0, arg1)
+}
+
+// GetShoppingList mocks base method.
+func (m *MockShoppingListRepository) GetShoppingList(arg0 context.Context, arg1 string) (*entity.ShoppingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShoppingList", arg0, arg1)
+	ret0, _ := ret[0].(*entity.ShoppingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShoppingList indicates an expected call of GetShoppingList.
+func (mr *MockShoppingListRepositoryMockRecorder) GetShoppingList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingList", reflect.TypeOf((*MockShoppingListRepository)(nil).GetShoppingList), arg0, arg1)
+}
+
+// GetShoppingLists mocks base method.
+func (m *MockShoppingListRepository) GetShoppingLists(arg0 context.Context, arg1 string) ([]entity.ShoppingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShoppingLists", arg0, arg1)
+	ret0, _ := ret[0].([]entity.ShoppingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShoppingLists indicates an expected call of GetShoppingLists.
+func (mr *MockShoppingListRepositoryMockRecorder) GetShoppingLists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingLists", reflect.TypeOf((*MockShoppingListRepository)(nil).GetShoppingLists), arg0, arg1)
+}
+
+// SetShoppingListItemChecked mocks base method.
+func (m *MockShoppingListRepository) SetShoppingListItemChecked(arg0 context.Context, arg1, arg2 string, arg3 bool, arg4 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetShoppingListItemChecked", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetShoppingListItemChecked indicates an expected call of SetShoppingListItemChecked.
+func (mr *MockShoppingListRepositoryMockRecorder) SetShoppingListItemChecked(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShoppingListItemChecked", reflect.TypeOf((*MockShoppingListRepository)(nil).SetShoppingListItemChecked), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockMealPlanRepository is a mock of MealPlanRepository interface.
+type MockMealPlanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMealPlanRepositoryMockRecorder
+}
+
+// MockMealPlanRepositoryMockRecorder is the mock recorder for MockMealPlanRepository.
+type MockMealPlanRepositoryMockRecorder struct {
+	mock *MockMealPlanRepository
+}
+
+// NewMockMealPlanRepository creates a new mock instance.
+func NewMockMealPlanRepository(ctrl *gomock.Controller) *MockMealPlanRepository {
+	mock := &MockMealPlanRepository{ctrl: ctrl}
+	mock.recorder = &MockMealPlanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMealPlanRepository) EXPECT() *MockMealPlanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMealPlan mocks base method.
+func (m *MockMealPlanRepository) CreateMealPlan(arg0 context.Context, arg1 *entity.MealPlan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMealPlan", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMealPlan indicates an expected call of CreateMealPlan.
+func (mr *MockMealPlanRepositoryMockRecorder) CreateMealPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMealPlan", reflect.TypeOf((*MockMealPlanRepository)(nil).CreateMealPlan), arg0, arg1)
+}
+
+// DeleteMealPlan mocks base method.
+func (m *MockMealPlanRepository) DeleteMealPlan(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMealPlan", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMealPlan indicates an expected call of DeleteMealPlan.
+func (mr *MockMealPlanRepositoryMockRecorder) DeleteMealPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMealPlan", reflect.TypeOf((*MockMealPlanRepository)(nil).DeleteMealPlan), arg0, arg1)
+}
+
+// GetMealPlan mocks base method.
+func (m *MockMealPlanRepository) GetMealPlan(arg0 context.Context, arg1 string) (*entity.MealPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMealPlan", arg0, arg1)
+	ret0, _ := ret[0].(*entity.MealPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMealPlan indicates an expected call of GetMealPlan.
+func (mr *MockMealPlanRepositoryMockRecorder) GetMealPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMealPlan", reflect.TypeOf((*MockMealPlanRepository)(nil).GetMealPlan), arg0, arg1)
+}
+
+// GetMealPlans mocks base method.
+func (m *MockMealPlanRepository) GetMealPlans(arg0 context.Context, arg1 string, arg2, arg3 time.Time) ([]entity.MealPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMealPlans", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]entity.MealPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMealPlans indicates an expected call of GetMealPlans.
+func (mr *MockMealPlanRepositoryMockRecorder) GetMealPlans(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMealPlans", reflect.TypeOf((*MockMealPlanRepository)(nil).GetMealPlans), arg0, arg1, arg2, arg3)
+}
+
+// MockHistoryRepository is a mock of HistoryRepository interface.
+type MockHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRepositoryMockRecorder
+}
+
+// MockHistoryRepositoryMockRecorder is the mock recorder for MockHistoryRepository.
+type MockHistoryRepositoryMockRecorder struct {
+	mock *MockHistoryRepository
+}
+
+// NewMockHistoryRepository creates a new mock instance.
+func NewMockHistoryRepository(ctrl *gomock.Controller) *MockHistoryRepository {
+	mock := &MockHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRepository) EXPECT() *MockHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AppendHistory mocks base method.
+func (m *MockHistoryRepository) AppendHistory(arg0 context.Context, arg1 *entity.HistoryRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendHistory indicates an expected call of AppendHistory.
+func (mr *MockHistoryRepositoryMockRecorder) AppendHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendHistory", reflect.TypeOf((*MockHistoryRepository)(nil).AppendHistory), arg0, arg1)
+}
+
+// GetHistory mocks base method.
+func (m *MockHistoryRepository) GetHistory(arg0 context.Context, arg1 string, arg2 *time.Time, arg3 *string) ([]entity.HistoryRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]entity.HistoryRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockHistoryRepositoryMockRecorder) GetHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockHistoryRepository)(nil).GetHistory), arg0, arg1, arg2, arg3)
+}
+
+// MockRatingRepository is a mock of RatingRepository interface.
+type MockRatingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingRepositoryMockRecorder
+}
+
+// MockRatingRepositoryMockRecorder is the mock recorder for MockRatingRepository.
+type MockRatingRepositoryMockRecorder struct {
+	mock *MockRatingRepository
+}
+
+// NewMockRatingRepository creates a new mock instance.
+func NewMockRatingRepository(ctrl *gomock.Controller) *MockRatingRepository {
+	mock := &MockRatingRepository{ctrl: ctrl}
+	mock.recorder = &MockRatingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingRepository) EXPECT() *MockRatingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteRatings mocks base method.
+func (m *MockRatingRepository) DeleteRatings(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRatings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRatings indicates an expected call of DeleteRatings.
+func (mr *MockRatingRepositoryMockRecorder) DeleteRatings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRatings", reflect.TypeOf((*MockRatingRepository)(nil).DeleteRatings), arg0, arg1)
+}
+
+// GetRating mocks base method.
+func (m *MockRatingRepository) GetRating(arg0 context.Context, arg1, arg2 string) (*entity.RecipeRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRating", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.RecipeRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRating indicates an expected call of GetRating.
+func (mr *MockRatingRepositoryMockRecorder) GetRating(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRating", reflect.TypeOf((*MockRatingRepository)(nil).GetRating), arg0, arg1, arg2)
+}
+
+// UpsertRating mocks base method.
+func (m *MockRatingRepository) UpsertRating(arg0 context.Context, arg1 *entity.RecipeRating) (*entity.RecipeRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRating", arg0, arg1)
+	ret0, _ := ret[0].(*entity.RecipeRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRating indicates an expected call of UpsertRating.
+func (mr *MockRatingRepositoryMockRecorder) UpsertRating(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRating", reflect.TypeOf((*MockRatingRepository)(nil).UpsertRating), arg0, arg1)
+}
+
+// MockSubstitutionRepository is a mock of SubstitutionRepository interface.
+type MockSubstitutionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubstitutionRepositoryMockRecorder
+}
+
+// MockSubstitutionRepositoryMockRecorder is the mock recorder for MockSubstitutionRepository.
+type MockSubstitutionRepositoryMockRecorder struct {
+	mock *MockSubstitutionRepository
+}
+
+// NewMockSubstitutionRepository creates a new mock instance.
+func NewMockSubstitutionRepository(ctrl *gomock.Controller) *MockSubstitutionRepository {
+	mock := &MockSubstitutionRepository{ctrl: ctrl}
+	mock.recorder = &MockSubstitutionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubstitutionRepository) EXPECT() *MockSubstitutionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSubstitutions mocks base method.
+func (m *MockSubstitutionRepository) GetSubstitutions(arg0 context.Context, arg1 []string) ([]entity.Substitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubstitutions", arg0, arg1)
+	ret0, _ := ret[0].([]entity.Substitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubstitutions indicates an expected call of GetSubstitutions.
+func (mr *MockSubstitutionRepositoryMockRecorder) GetSubstitutions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubstitutions", reflect.TypeOf((*MockSubstitutionRepository)(nil).GetSubstitutions), arg0, arg1)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithTransaction mocks base method.
+func (m *MockTransactor) WithTransaction(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockTransactorMockRecorder) WithTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockTransactor)(nil).WithTransaction), arg0, arg1)
+}
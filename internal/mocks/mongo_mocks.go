@@ -93,6 +93,20 @@ func (mr *MockMongoDBMockRecorder) Ping(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockMongoDB)(nil).Ping), arg0)
 }
 
+// WithTransaction mocks base method.
+func (m *MockMongoDB) WithTransaction(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockMongoDBMockRecorder) WithTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockMongoDB)(nil).WithTransaction), arg0, arg1)
+}
+
 // MockMongoDatabase is a mock of MongoDatabase interface.
 type MockMongoDatabase struct {
 	ctrl     *gomock.Controller
@@ -289,6 +303,25 @@ func (mr *MockMongoCollectionMockRecorder) FindOne(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockMongoCollection)(nil).FindOne), arg0, arg1)
 }
 
+// FindOneAndUpdate mocks base method.
+func (m *MockMongoCollection) FindOneAndUpdate(arg0 context.Context, arg1, arg2 interface{}, arg3 ...*options.FindOneAndUpdateOptions) mongo.MongoSingleResult {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindOneAndUpdate", varargs...)
+	ret0, _ := ret[0].(mongo.MongoSingleResult)
+	return ret0
+}
+
+// FindOneAndUpdate indicates an expected call of FindOneAndUpdate.
+func (mr *MockMongoCollectionMockRecorder) FindOneAndUpdate(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneAndUpdate", reflect.TypeOf((*MockMongoCollection)(nil).FindOneAndUpdate), varargs...)
+}
+
 // Indexes mocks base method.
 func (m *MockMongoCollection) Indexes() mongo.MongoIndexView {
 	m.ctrl.T.Helper()
@@ -334,18 +367,23 @@ func (mr *MockMongoCollectionMockRecorder) InsertOne(arg0, arg1 interface{}) *go
 }
 
 // ReplaceOne mocks base method.
-func (m *MockMongoCollection) ReplaceOne(arg0 context.Context, arg1, arg2 interface{}) (mongo.MongoUpdateResult, error) {
+func (m *MockMongoCollection) ReplaceOne(arg0 context.Context, arg1, arg2 interface{}, arg3 ...*options.ReplaceOptions) (mongo.MongoUpdateResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReplaceOne", arg0, arg1, arg2)
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReplaceOne", varargs...)
 	ret0, _ := ret[0].(mongo.MongoUpdateResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReplaceOne indicates an expected call of ReplaceOne.
-func (mr *MockMongoCollectionMockRecorder) ReplaceOne(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockMongoCollectionMockRecorder) ReplaceOne(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceOne", reflect.TypeOf((*MockMongoCollection)(nil).ReplaceOne), arg0, arg1, arg2)
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceOne", reflect.TypeOf((*MockMongoCollection)(nil).ReplaceOne), varargs...)
 }
 
 // UpdateMany mocks base method.
@@ -364,18 +402,23 @@ func (mr *MockMongoCollectionMockRecorder) UpdateMany(arg0, arg1, arg2 interface
 }
 
 // UpdateOne mocks base method.
-func (m *MockMongoCollection) UpdateOne(arg0 context.Context, arg1, arg2 interface{}) (mongo.MongoUpdateResult, error) {
+func (m *MockMongoCollection) UpdateOne(arg0 context.Context, arg1, arg2 interface{}, arg3 ...*options.UpdateOptions) (mongo.MongoUpdateResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOne", arg0, arg1, arg2)
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateOne", varargs...)
 	ret0, _ := ret[0].(mongo.MongoUpdateResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateOne indicates an expected call of UpdateOne.
-func (mr *MockMongoCollectionMockRecorder) UpdateOne(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockMongoCollectionMockRecorder) UpdateOne(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOne", reflect.TypeOf((*MockMongoCollection)(nil).UpdateOne), arg0, arg1, arg2)
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOne", reflect.TypeOf((*MockMongoCollection)(nil).UpdateOne), varargs...)
 }
 
 // MockMongoCursor is a mock of MongoCursor interface.
@@ -0,0 +1,52 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type HistoryRepo struct {
+	Collection MongoCollection
+	Logger     *zap.Logger
+}
+
+// Ensure it implements the interface
+var _ repository.HistoryRepository = (*HistoryRepo)(nil)
+
+func (m *HistoryRepo) AppendHistory(ctx context.Context, record *entity.HistoryRecord) error {
+	_, err := m.Collection.InsertOne(ctx, record)
+	if err != nil {
+		m.Logger.Error("Failed to append history record", zap.String("pantryId", record.PantryID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (m *HistoryRepo) GetHistory(ctx context.Context, pantryID string, since *time.Time, entryID *string) ([]entity.HistoryRecord, error) {
+	filter := bson.M{"pantryId": pantryID}
+	if since != nil {
+		filter["timestamp"] = bson.M{"$gte": *since}
+	}
+	if entryID != nil {
+		filter["entryId"] = *entryID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+	cursor, err := m.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		m.Logger.Error("Failed to get pantry history", zap.Error(err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	records := []entity.HistoryRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -14,6 +14,9 @@ type MongoDB interface {
 	Connect(ctx context.Context) error
 	Ping(ctx context.Context) error
 	Disconnect(ctx context.Context) error
+	// WithTransaction runs fn in a multi-document transaction. It needs a
+	// replica set or sharded cluster.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // MongoDatabase represents MongoDB database interface
@@ -31,12 +34,13 @@ type MongoCollection interface {
 
 	// Find operations
 	FindOne(ctx context.Context, filter interface{}) MongoSingleResult
+	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) MongoSingleResult
 	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (MongoCursor, error)
 
 	// Update operations
-	UpdateOne(ctx context.Context, filter interface{}, update interface{}) (MongoUpdateResult, error)
+	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (MongoUpdateResult, error)
 	UpdateMany(ctx context.Context, filter interface{}, update interface{}) (MongoUpdateResult, error)
-	ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}) (MongoUpdateResult, error)
+	ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (MongoUpdateResult, error)
 
 	// Delete operations
 	DeleteOne(ctx context.Context, filter interface{}) (MongoDeleteResult, error)
@@ -0,0 +1,64 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type InviteRepo struct {
+	Collection MongoCollection
+	Logger     *zap.Logger
+}
+
+// Ensure it implements the interface
+var _ repository.InviteRepository = (*InviteRepo)(nil)
+
+func (m *InviteRepo) CreateInvite(ctx context.Context, invite *entity.PantryInvite) error {
+	_, err := m.Collection.InsertOne(ctx, invite)
+	if err != nil {
+		m.Logger.Error("Failed to create pantry invite", zap.Error(err))
+		return err
+	}
+	m.Logger.Info("Created pantry invite", zap.String("pantryId", invite.PantryID), zap.String("email", invite.Email))
+	return nil
+}
+
+func (m *InviteRepo) GetInvite(ctx context.Context, code string) (*entity.PantryInvite, error) {
+	var invite entity.PantryInvite
+	err := m.Collection.FindOne(ctx, bson.M{"code": code}).Decode(&invite)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", repository.ErrInviteNotFound, code)
+		}
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// AcceptInvite marks an invite as used. The filter only matches invites that
+// are unused and unexpired, so an invite can be redeemed at most once.
+func (m *InviteRepo) AcceptInvite(ctx context.Context, code string, userID string, now time.Time) error {
+	filter := bson.M{
+		"code":       code,
+		"acceptedAt": bson.M{"$exists": false},
+		"expiresAt":  bson.M{"$gt": now},
+	}
+	update := bson.M{"$set": bson.M{"acceptedAt": now, "acceptedBy": userID}}
+	result, err := m.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		m.Logger.Error("Failed to accept pantry invite", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrInviteNotFound, code)
+	}
+	return nil
+}
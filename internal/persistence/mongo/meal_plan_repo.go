@@ -0,0 +1,77 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type MealPlanRepo struct {
+	Collection MongoCollection
+	Logger     *zap.Logger
+}
+
+// Ensure it implements the interface
+var _ repository.MealPlanRepository = (*MealPlanRepo)(nil)
+
+func (m *MealPlanRepo) CreateMealPlan(ctx context.Context, plan *entity.MealPlan) error {
+	_, err := m.Collection.InsertOne(ctx, plan)
+	if err != nil {
+		m.Logger.Error("Failed to create meal plan", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (m *MealPlanRepo) GetMealPlan(ctx context.Context, planID string) (*entity.MealPlan, error) {
+	var plan entity.MealPlan
+	err := m.Collection.FindOne(ctx, bson.M{"id": planID}).Decode(&plan)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", repository.ErrMealPlanNotFound, planID)
+		}
+		m.Logger.Error("Failed to get meal plan", zap.Error(err))
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (m *MealPlanRepo) GetMealPlans(ctx context.Context, pantryID string, from time.Time, to time.Time) ([]entity.MealPlan, error) {
+	filter := bson.M{
+		"pantryId": pantryID,
+		"date":     bson.M{"$gte": from, "$lte": to},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "createdAt", Value: 1}})
+	cursor, err := m.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		m.Logger.Error("Failed to get meal plans", zap.Error(err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	plans := []entity.MealPlan{}
+	if err := cursor.All(ctx, &plans); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+func (m *MealPlanRepo) DeleteMealPlan(ctx context.Context, planID string) error {
+	result, err := m.Collection.DeleteOne(ctx, bson.M{"id": planID})
+	if err != nil {
+		m.Logger.Error("Failed to delete meal plan", zap.Error(err))
+		return err
+	}
+	if result.DeletedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrMealPlanNotFound, planID)
+	}
+	return nil
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"time"
 
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+var _ repository.Transactor = (*mongoClient)(nil)
+
 // mongoClient implements MongoDB interface with real mongo client
 type mongoClient struct {
 	client *mongo.Client
@@ -42,6 +45,19 @@ func (c *mongoClient) Disconnect(ctx context.Context) error {
 	return c.client.Disconnect(ctx)
 }
 
+func (c *mongoClient) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := c.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
 // mongoDatabase implements MongoDatabase interface
 type mongoDatabase struct {
 	db *mongo.Database
@@ -84,6 +100,10 @@ func (c *mongoCollection) FindOne(ctx context.Context, filter interface{}) Mongo
 	return &mongoSingleResult{result: c.coll.FindOne(ctx, filter)}
 }
 
+func (c *mongoCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) MongoSingleResult {
+	return &mongoSingleResult{result: c.coll.FindOneAndUpdate(ctx, filter, update, opts...)}
+}
+
 func (c *mongoCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (MongoCursor, error) {
 	cursor, err := c.coll.Find(ctx, filter, opts...)
 	if err != nil {
@@ -92,8 +112,8 @@ func (c *mongoCollection) Find(ctx context.Context, filter interface{}, opts ...
 	return &mongoCursor{cursor: cursor}, nil
 }
 
-func (c *mongoCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}) (MongoUpdateResult, error) {
-	result, err := c.coll.UpdateOne(ctx, filter, update)
+func (c *mongoCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (MongoUpdateResult, error) {
+	result, err := c.coll.UpdateOne(ctx, filter, update, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -108,8 +128,8 @@ func (c *mongoCollection) UpdateMany(ctx context.Context, filter interface{}, up
 	return &mongoUpdateResult{result: result}, nil
 }
 
-func (c *mongoCollection) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}) (MongoUpdateResult, error) {
-	result, err := c.coll.ReplaceOne(ctx, filter, replacement)
+func (c *mongoCollection) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (MongoUpdateResult, error) {
+	result, err := c.coll.ReplaceOne(ctx, filter, replacement, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3,10 +3,14 @@ package mongo
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/thisausername99/pantry_butler/internal/domain/entity"
 	"github.com/thisausername99/pantry_butler/internal/domain/repository"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
@@ -49,13 +53,50 @@ func (m *PantryEntryRepo) GetPantryEntries(ctx context.Context, pantryID string)
 	return *pantry.Entries, nil
 }
 
+// FindPantryEntries returns the entries of a pantry that match the filter.
+// The category and tags predicates are first applied to the pantry itself,
+// where the pantry_entries indexes can serve them; entries are then unwound
+// into their own documents so the full filter picks out the matching ones.
+func (m *PantryEntryRepo) FindPantryEntries(ctx context.Context, pantryID string, filter *entity.PantryEntryFilter) ([]entity.PantryEntry, error) {
+	pantryMatch := bson.M{"id": pantryID}
+	match := bson.M{}
+	if filter.LocationID != nil {
+		match["locationId"] = *filter.LocationID
+	}
+	if filter.Category != nil {
+		pantryMatch["pantry_entries.category"] = *filter.Category
+		match["category"] = *filter.Category
+	}
+	if len(filter.Tags) > 0 {
+		pantryMatch["pantry_entries.tags"] = bson.M{"$all": filter.Tags}
+		match["tags"] = bson.M{"$all": filter.Tags}
+	}
+	pipeline := []bson.M{
+		{"$match": pantryMatch},
+		{"$unwind": "$pantry_entries"},
+		{"$replaceRoot": bson.M{"newRoot": "$pantry_entries"}},
+		{"$match": match},
+	}
+
+	cursor, err := m.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		m.Logger.Error("Failed to find pantry entries", zap.Error(err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []entity.PantryEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
 func (m *PantryEntryRepo) InsertPantryEntry(ctx context.Context, pantryID string, entry *entity.PantryEntry) error {
 	if entry.Name == "" {
 		return errors.New("entry does not have a name")
 	}
 
-	//! TODO: Add expiration date or generate one
-
 	// Add the entry to the pantry's pantry_entries array where id == pantryID
 	filter := bson.M{"id": pantryID}
 	update := bson.M{
@@ -72,16 +113,381 @@ func (m *PantryEntryRepo) InsertPantryEntry(ctx context.Context, pantryID string
 	return nil
 }
 
+func (m *PantryEntryRepo) InsertPantryEntries(ctx context.Context, pantryID string, entries []entity.PantryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	filter := bson.M{"id": pantryID}
+	update := bson.M{
+		"$push": bson.M{
+			"pantry_entries": bson.M{"$each": entries},
+		},
+	}
+	result, err := m.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		m.Logger.Error("Failed to insert pantry entries", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrPantryNotFound, pantryID)
+	}
+	m.Logger.Info("Inserted pantry entries", zap.String("pantryId", pantryID), zap.Int("count", len(entries)))
+	return nil
+}
+
+func (m *PantryEntryRepo) IncrementPantryEntry(ctx context.Context, pantryID string, entryID string, amount float64, expiration *time.Time) error {
+	update := bson.M{"$inc": bson.M{"pantry_entries.$.quantity": amount}}
+	if expiration != nil {
+		update["$min"] = bson.M{"pantry_entries.$.expiration": *expiration}
+	}
+	filter := bson.M{"id": pantryID, "pantry_entries.id": entryID}
+	result, err := m.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		m.Logger.Error("Failed to increment pantry entry", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return m.missingPantryOrEntry(ctx, pantryID, entryID)
+	}
+	return nil
+}
+
+// MergePantryEntries rewrites the entries array with an update pipeline so
+// that replacing the kept entry and dropping the merged ones happen in one
+// write. keep is written as given, so callers must read it in the same
+// transaction for changes made since to be detected rather than overwritten.
+// The kept entry is wrapped in $literal so that values starting with "$" are
+// not read as field paths.
+func (m *PantryEntryRepo) MergePantryEntries(ctx context.Context, pantryID string, keep *entity.PantryEntry, removeIDs []string) error {
+	pipeline := bson.A{
+		bson.M{"$set": bson.M{
+			"pantry_entries": bson.M{"$map": bson.M{
+				"input": bson.M{"$filter": bson.M{
+					"input": "$pantry_entries",
+					"cond":  bson.M{"$not": bson.A{bson.M{"$in": bson.A{"$$this.id", removeIDs}}}},
+				}},
+				"in": bson.M{"$cond": bson.A{
+					bson.M{"$eq": bson.A{"$$this.id", keep.ID}},
+					bson.M{"$literal": keep},
+					"$$this",
+				}},
+			}},
+		}},
+	}
+	filter := bson.M{"id": pantryID, "pantry_entries.id": keep.ID}
+	result, err := m.Collection.UpdateOne(ctx, filter, pipeline)
+	if err != nil {
+		m.Logger.Error("Failed to merge pantry entries", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return m.missingPantryOrEntry(ctx, pantryID, keep.ID)
+	}
+	m.Logger.Info("Merged pantry entries", zap.String("entryId", keep.ID), zap.Strings("removed", removeIDs))
+	return nil
+}
+
+func (m *PantryEntryRepo) UpdatePantryEntry(ctx context.Context, pantryID string, entryID string, patch *entity.PantryEntryPatch) error {
+	// Only the supplied fields are written; the positional operator targets
+	// the element matched by "pantry_entries.id" in the filter.
+	set := bson.M{}
+	if patch.Name != nil {
+		if *patch.Name == "" {
+			return errors.New("entry name cannot be empty")
+		}
+		set["pantry_entries.$.name"] = *patch.Name
+	}
+	if patch.IngredientID != nil {
+		set["pantry_entries.$.ingredientId"] = *patch.IngredientID
+	}
+	if patch.Quantity != nil {
+		set["pantry_entries.$.quantity"] = *patch.Quantity
+	}
+	if patch.QuantityType != nil {
+		set["pantry_entries.$.quantityType"] = *patch.QuantityType
+	}
+	if patch.Expiration != nil {
+		set["pantry_entries.$.expiration"] = *patch.Expiration
+	}
+	if patch.Category != nil {
+		set["pantry_entries.$.category"] = *patch.Category
+	}
+	if patch.Tags != nil {
+		set["pantry_entries.$.tags"] = patch.Tags
+	}
+	if len(set) == 0 {
+		return errors.New("patch does not contain any fields")
+	}
+
+	filter := bson.M{"id": pantryID, "pantry_entries.id": entryID}
+	result, err := m.Collection.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		m.Logger.Error("Failed to update pantry entry", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return m.missingPantryOrEntry(ctx, pantryID, entryID)
+	}
+	m.Logger.Info("Updated pantry entry", zap.String("entryId", entryID), zap.Any("fields", set))
+	return nil
+}
+
+// depletedEpsilon absorbs floating point residue left by repeated $inc so an
+// entry consumed down to "zero" is still removed.
+const depletedEpsilon = 1e-9
+
+// ConsumePantryEntry decrements an entry's quantity by amount, expressed in
+// the entry's own unit. The decrement only applies while enough is on hand,
+// so concurrent consumers cannot drive the quantity negative. An entry that
+// reaches zero is removed in the same update and reported as depleted.
+func (m *PantryEntryRepo) ConsumePantryEntry(ctx context.Context, pantryID string, entryID string, amount float64) (bool, error) {
+	if amount <= 0 {
+		return false, errors.New("amount must be positive")
+	}
+
+	filter := bson.M{
+		"id": pantryID,
+		"pantry_entries": bson.M{"$elemMatch": bson.M{
+			"id":       entryID,
+			"quantity": bson.M{"$gte": amount - depletedEpsilon},
+		}},
+	}
+	isEntry := bson.M{"$eq": bson.A{"$$this.id", entryID}}
+	pipeline := bson.A{
+		bson.M{"$set": bson.M{
+			"pantry_entries": bson.M{"$map": bson.M{
+				"input": "$pantry_entries",
+				"in": bson.M{"$cond": bson.A{
+					isEntry,
+					bson.M{"$mergeObjects": bson.A{"$$this", bson.M{"quantity": bson.M{"$subtract": bson.A{"$$this.quantity", amount}}}}},
+					"$$this",
+				}},
+			}},
+		}},
+		bson.M{"$set": bson.M{
+			"pantry_entries": bson.M{"$filter": bson.M{
+				"input": "$pantry_entries",
+				"cond": bson.M{"$not": bson.A{bson.M{"$and": bson.A{
+					isEntry,
+					bson.M{"$lte": bson.A{"$$this.quantity", depletedEpsilon}},
+				}}}},
+			}},
+		}},
+	}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"pantry_entries": bson.M{"$elemMatch": bson.M{"id": entryID}}})
+
+	var pantry entity.Pantry
+	err := m.Collection.FindOneAndUpdate(ctx, filter, pipeline, opts).Decode(&pantry)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		count, err := m.Collection.CountDocuments(ctx, bson.M{"id": pantryID, "pantry_entries.id": entryID})
+		if err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return false, fmt.Errorf("%w: entry %s", repository.ErrInsufficientQuantity, entryID)
+		}
+		return false, m.missingPantryOrEntry(ctx, pantryID, entryID)
+	}
+	if err != nil {
+		m.Logger.Error("Failed to consume pantry entry", zap.Error(err))
+		return false, err
+	}
+
+	depleted := pantry.Entries == nil || len(*pantry.Entries) == 0
+	m.Logger.Info("Consumed pantry entry",
+		zap.String("entryId", entryID),
+		zap.Float64("amount", amount),
+		zap.Bool("depleted", depleted),
+	)
+	return depleted, nil
+}
+
+// missingPantryOrEntry reports which of the pantry or the entry is absent
+// after a filter on both matched nothing.
+func (m *PantryEntryRepo) missingPantryOrEntry(ctx context.Context, pantryID string, entryID string) error {
+	count, err := m.Collection.CountDocuments(ctx, bson.M{"id": pantryID})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrPantryNotFound, pantryID)
+	}
+	return fmt.Errorf("%w: %s", repository.ErrPantryEntryNotFound, entryID)
+}
+
+// DeletePantryEntry removes an entry from its pantry's pantry_entries.
 func (m *PantryEntryRepo) DeletePantryEntry(ctx context.Context, pantryID string, entryID string) error {
-	_, err := m.Collection.DeleteOne(ctx, bson.M{"pantryId": pantryID, "entryId": entryID})
+	update := bson.M{"$pull": bson.M{"pantry_entries": bson.M{"id": entryID}}}
+	result, err := m.Collection.UpdateOne(ctx, bson.M{"id": pantryID}, update)
 	if err != nil {
 		m.Logger.Error("Failed to delete pantry entry", zap.Error(err))
 		return err
 	}
+	if result.ModifiedCount() == 0 {
+		return m.missingPantryOrEntry(ctx, pantryID, entryID)
+	}
 	m.Logger.Info("Deleted pantry entry", zap.String("entryId", entryID))
 	return nil
 }
 
+func (m *PantryEntryRepo) GetPantry(ctx context.Context, pantryID string) (*entity.Pantry, error) {
+	var pantry entity.Pantry
+	err := m.Collection.FindOne(ctx, bson.M{"id": pantryID}).Decode(&pantry)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", repository.ErrPantryNotFound, pantryID)
+		}
+		m.Logger.Error("Failed to find pantry", zap.Error(err))
+		return nil, err
+	}
+	return &pantry, nil
+}
+
+// AddPantryMember adds a member to a pantry, replacing any existing
+// membership of the same user in the same update so a user never holds two
+// roles and is never left without one.
+func (m *PantryEntryRepo) AddPantryMember(ctx context.Context, pantryID string, member *entity.PantryMember) error {
+	pipeline := bson.A{
+		bson.M{"$set": bson.M{
+			"members": bson.M{"$concatArrays": bson.A{
+				bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$members", bson.A{}}},
+					"cond":  bson.M{"$ne": bson.A{"$$this.userId", member.UserID}},
+				}},
+				bson.A{bson.M{"$literal": member}},
+			}},
+		}},
+	}
+	result, err := m.Collection.UpdateOne(ctx, bson.M{"id": pantryID}, pipeline)
+	if err != nil {
+		m.Logger.Error("Failed to add pantry member", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrPantryNotFound, pantryID)
+	}
+	m.Logger.Info("Added pantry member", zap.String("pantryId", pantryID), zap.String("userId", member.UserID))
+	return nil
+}
+
+func (m *PantryEntryRepo) RemovePantryMember(ctx context.Context, pantryID string, userID string) error {
+	result, err := m.Collection.UpdateOne(ctx, bson.M{"id": pantryID}, bson.M{"$pull": bson.M{"members": bson.M{"userId": userID}}})
+	if err != nil {
+		m.Logger.Error("Failed to remove pantry member", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrPantryNotFound, pantryID)
+	}
+	m.Logger.Info("Removed pantry member", zap.String("pantryId", pantryID), zap.String("userId", userID))
+	return nil
+}
+
+func (m *PantryEntryRepo) AddStorageLocation(ctx context.Context, pantryID string, location *entity.StorageLocation) error {
+	result, err := m.Collection.UpdateOne(ctx, bson.M{"id": pantryID}, bson.M{"$push": bson.M{"locations": location}})
+	if err != nil {
+		m.Logger.Error("Failed to add storage location", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrPantryNotFound, pantryID)
+	}
+	m.Logger.Info("Added storage location", zap.String("pantryId", pantryID), zap.String("locationId", location.ID))
+	return nil
+}
+
+// RemoveStorageLocation deletes a location and unassigns every entry that
+// was stored in it, in a single update.
+func (m *PantryEntryRepo) RemoveStorageLocation(ctx context.Context, pantryID string, locationID string) error {
+	filter := bson.M{"id": pantryID, "locations.id": locationID}
+	update := bson.M{
+		"$pull":  bson.M{"locations": bson.M{"id": locationID}},
+		"$unset": bson.M{"pantry_entries.$[e].locationId": ""},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"e.locationId": locationID}},
+	})
+	result, err := m.Collection.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		m.Logger.Error("Failed to remove storage location", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return m.missingPantryOrLocation(ctx, pantryID, locationID)
+	}
+	m.Logger.Info("Removed storage location", zap.String("pantryId", pantryID), zap.String("locationId", locationID))
+	return nil
+}
+
+// MovePantryEntry assigns an entry to one of the pantry's storage locations.
+// The filter requires the location to exist so an entry can never point at a
+// location that was removed concurrently.
+func (m *PantryEntryRepo) MovePantryEntry(ctx context.Context, pantryID string, entryID string, locationID string) error {
+	filter := bson.M{"id": pantryID, "pantry_entries.id": entryID, "locations.id": locationID}
+	update := bson.M{"$set": bson.M{"pantry_entries.$[e].locationId": locationID}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"e.id": entryID}},
+	})
+	result, err := m.Collection.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		m.Logger.Error("Failed to move pantry entry", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		count, err := m.Collection.CountDocuments(ctx, bson.M{"id": pantryID, "pantry_entries.id": entryID})
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %s", repository.ErrLocationNotFound, locationID)
+		}
+		return m.missingPantryOrEntry(ctx, pantryID, entryID)
+	}
+	m.Logger.Info("Moved pantry entry", zap.String("entryId", entryID), zap.String("locationId", locationID))
+	return nil
+}
+
+func (m *PantryEntryRepo) TagPantryEntry(ctx context.Context, pantryID string, entryID string, tags []string) error {
+	filter := bson.M{"id": pantryID, "pantry_entries.id": entryID}
+	update := bson.M{"$addToSet": bson.M{"pantry_entries.$.tags": bson.M{"$each": tags}}}
+	result, err := m.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		m.Logger.Error("Failed to tag pantry entry", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return m.missingPantryOrEntry(ctx, pantryID, entryID)
+	}
+	return nil
+}
+
+func (m *PantryEntryRepo) UntagPantryEntry(ctx context.Context, pantryID string, entryID string, tags []string) error {
+	filter := bson.M{"id": pantryID, "pantry_entries.id": entryID}
+	update := bson.M{"$pullAll": bson.M{"pantry_entries.$.tags": tags}}
+	result, err := m.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		m.Logger.Error("Failed to untag pantry entry", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return m.missingPantryOrEntry(ctx, pantryID, entryID)
+	}
+	return nil
+}
+
+func (m *PantryEntryRepo) missingPantryOrLocation(ctx context.Context, pantryID string, locationID string) error {
+	count, err := m.Collection.CountDocuments(ctx, bson.M{"id": pantryID})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrPantryNotFound, pantryID)
+	}
+	return fmt.Errorf("%w: %s", repository.ErrLocationNotFound, locationID)
+}
+
 func (m *PantryEntryRepo) CreateNewPantry(ctx context.Context, pantry *entity.Pantry) error {
 	_, err := m.Collection.InsertOne(ctx, pantry)
 	if err != nil {
@@ -94,10 +500,13 @@ func (m *PantryEntryRepo) CreateNewPantry(ctx context.Context, pantry *entity.Pa
 }
 
 func (m *PantryEntryRepo) DeletePantry(ctx context.Context, pantryID string) error {
-	_, err := m.Collection.DeleteOne(ctx, bson.M{"pantryId": pantryID})
+	result, err := m.Collection.DeleteOne(ctx, bson.M{"id": pantryID})
 	if err != nil {
 		m.Logger.Error("Failed to delete pantry", zap.Error(err))
 		return err
 	}
+	if result.DeletedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrPantryNotFound, pantryID)
+	}
 	return nil
 }
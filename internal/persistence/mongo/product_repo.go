@@ -0,0 +1,50 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type ProductRepo struct {
+	Collection MongoCollection
+	Logger     *zap.Logger
+}
+
+// Ensure it implements the interface
+var _ repository.ProductRepository = (*ProductRepo)(nil)
+
+func (m *ProductRepo) GetProduct(ctx context.Context, code string) (*entity.Product, error) {
+	var product entity.Product
+	err := m.Collection.FindOne(ctx, bson.M{"code": code}).Decode(&product)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", repository.ErrProductNotFound, code)
+		}
+		m.Logger.Error("Failed to get product", zap.String("code", code), zap.Error(err))
+		return nil, err
+	}
+	return &product, nil
+}
+
+// UpsertProduct inserts a product or replaces the product already stored
+// under the same code, so fields the catalog no longer has are cleared.
+func (m *ProductRepo) UpsertProduct(ctx context.Context, product *entity.Product) error {
+	_, err := m.Collection.ReplaceOne(ctx,
+		bson.M{"code": product.Code},
+		product,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		m.Logger.Error("Failed to upsert product", zap.String("code", product.Code), zap.Error(err))
+		return err
+	}
+	return nil
+}
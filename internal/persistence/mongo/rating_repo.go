@@ -0,0 +1,72 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type RatingRepo struct {
+	Collection MongoCollection
+	Logger     *zap.Logger
+}
+
+// Ensure it implements the interface
+var _ repository.RatingRepository = (*RatingRepo)(nil)
+
+// UpsertRating writes the rating in a single findAndModify so that the
+// rating it replaces is read atomically with the write.
+func (m *RatingRepo) UpsertRating(ctx context.Context, rating *entity.RecipeRating) (*entity.RecipeRating, error) {
+	filter := bson.M{"recipeId": rating.RecipeID, "userId": rating.UserID}
+	set := bson.M{"stars": rating.Stars, "updatedAt": rating.UpdatedAt}
+	update := bson.M{
+		"$set":         set,
+		"$setOnInsert": bson.M{"createdAt": rating.CreatedAt},
+	}
+	if rating.Note != nil {
+		set["note"] = *rating.Note
+	} else {
+		update["$unset"] = bson.M{"note": ""}
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+	var previous entity.RecipeRating
+	err := m.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		m.Logger.Error("Failed to save rating", zap.String("recipeId", rating.RecipeID), zap.Error(err))
+		return nil, err
+	}
+	return &previous, nil
+}
+
+func (m *RatingRepo) GetRating(ctx context.Context, recipeID string, userID string) (*entity.RecipeRating, error) {
+	var rating entity.RecipeRating
+	err := m.Collection.FindOne(ctx, bson.M{"recipeId": recipeID, "userId": userID}).Decode(&rating)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", repository.ErrRatingNotFound, recipeID)
+		}
+		m.Logger.Error("Failed to get rating", zap.String("recipeId", recipeID), zap.Error(err))
+		return nil, err
+	}
+	return &rating, nil
+}
+
+func (m *RatingRepo) DeleteRatings(ctx context.Context, recipeID string) error {
+	_, err := m.Collection.DeleteMany(ctx, bson.M{"recipeId": recipeID})
+	if err != nil {
+		m.Logger.Error("Failed to delete ratings", zap.String("recipeId", recipeID), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -2,10 +2,13 @@ package mongo
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/thisausername99/pantry_butler/internal/domain/entity"
 	"github.com/thisausername99/pantry_butler/internal/domain/repository"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
@@ -37,6 +40,19 @@ func (m *RecipeRepo) GetRecipes(ctx context.Context) ([]entity.Recipe, error) {
 	return recipes, nil
 }
 
+func (m *RecipeRepo) GetRecipe(ctx context.Context, recipeID string) (*entity.Recipe, error) {
+	var recipe entity.Recipe
+	err := m.Collection.FindOne(ctx, bson.M{"id": recipeID}).Decode(&recipe)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", repository.ErrRecipeNotFound, recipeID)
+		}
+		m.Logger.Error("Failed to get recipe", zap.String("recipeId", recipeID), zap.Error(err))
+		return nil, err
+	}
+	return &recipe, nil
+}
+
 func (m *RecipeRepo) GetRecipesByCuisine(ctx context.Context, cuisine string) ([]entity.Recipe, error) {
 	var recipes []entity.Recipe
 	filter := bson.M{"cuisine": cuisine}
@@ -57,3 +73,163 @@ func (m *RecipeRepo) GetRecipesByCuisine(ctx context.Context, cuisine string) ([
 	}
 	return recipes, nil
 }
+
+func (m *RecipeRepo) GetRecipesByIDs(ctx context.Context, recipeIDs []string) ([]entity.Recipe, error) {
+	var recipes []entity.Recipe
+	filter := bson.M{"id": bson.M{"$in": recipeIDs}}
+	cursor, err := m.Collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var recipe entity.Recipe
+		if err := cursor.Decode(&recipe); err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, recipe)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return recipes, nil
+}
+
+func (m *RecipeRepo) CreateRecipe(ctx context.Context, recipe *entity.Recipe) error {
+	_, err := m.Collection.InsertOne(ctx, recipe)
+	if err != nil {
+		m.Logger.Error("Failed to create recipe", zap.Error(err))
+		return err
+	}
+	m.Logger.Info("Created recipe", zap.String("recipeId", recipe.ID))
+	return nil
+}
+
+// UpdateRecipe replaces the editable fields of a recipe. Fields maintained
+// elsewhere, such as its author, are left as stored.
+func (m *RecipeRepo) UpdateRecipe(ctx context.Context, recipe *entity.Recipe) error {
+	set := bson.M{
+		"name":        recipe.Name,
+		"description": recipe.Description,
+		"ingredients": recipe.Ingredients,
+		"steps":       recipe.Steps,
+	}
+	unset := bson.M{}
+	if recipe.Cuisine != nil {
+		set["cuisine"] = *recipe.Cuisine
+	} else {
+		unset["cuisine"] = ""
+	}
+	if recipe.Difficulty != nil {
+		set["difficulty"] = *recipe.Difficulty
+	} else {
+		unset["difficulty"] = ""
+	}
+	if recipe.SourceUrl != nil {
+		set["source_url"] = *recipe.SourceUrl
+	} else {
+		unset["source_url"] = ""
+	}
+	if recipe.Servings != nil {
+		set["servings"] = *recipe.Servings
+	} else {
+		unset["servings"] = ""
+	}
+	if recipe.PrepTime != nil {
+		set["prep_time"] = *recipe.PrepTime
+	} else {
+		unset["prep_time"] = ""
+	}
+	if recipe.CookTime != nil {
+		set["cook_time"] = *recipe.CookTime
+	} else {
+		unset["cook_time"] = ""
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := m.Collection.UpdateOne(ctx, bson.M{"id": recipe.ID}, update)
+	if err != nil {
+		m.Logger.Error("Failed to update recipe", zap.String("recipeId", recipe.ID), zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrRecipeNotFound, recipe.ID)
+	}
+	m.Logger.Info("Updated recipe", zap.String("recipeId", recipe.ID))
+	return nil
+}
+
+func (m *RecipeRepo) DeleteRecipe(ctx context.Context, recipeID string) error {
+	result, err := m.Collection.DeleteOne(ctx, bson.M{"id": recipeID})
+	if err != nil {
+		m.Logger.Error("Failed to delete recipe", zap.String("recipeId", recipeID), zap.Error(err))
+		return err
+	}
+	if result.DeletedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrRecipeNotFound, recipeID)
+	}
+	m.Logger.Info("Deleted recipe", zap.String("recipeId", recipeID))
+	return nil
+}
+
+// indexNotFoundCode is the server error returned by $text when the
+// collection has no text index.
+const indexNotFoundCode = 27
+
+// SearchRecipes runs a $text query against the recipe text index, ranking by
+// text score and then by ID so pages do not overlap.
+func (m *RecipeRepo) SearchRecipes(ctx context.Context, query string, offset int, limit int) ([]entity.RecipeSearchHit, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$text": bson.M{"$search": query}}}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "id", Value: 1}}}},
+		{{Key: "$skip", Value: int64(offset)}},
+		{{Key: "$limit", Value: int64(limit)}},
+	}
+	cursor, err := m.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		var serverErr mongo.ServerError
+		if errors.As(err, &serverErr) && serverErr.HasErrorCode(indexNotFoundCode) {
+			return nil, repository.ErrSearchUnsupported
+		}
+		m.Logger.Error("Failed to search recipes", zap.String("query", query), zap.Error(err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	hits := []entity.RecipeSearchHit{}
+	if err := cursor.All(ctx, &hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+func (m *RecipeRepo) AdjustRecipeRating(ctx context.Context, recipeID string, starsDelta int, countDelta int) error {
+	// A pipeline update so the average is derived from the adjusted totals
+	// within the same atomic write.
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"ratingTotal": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$ratingTotal", 0}}, starsDelta}},
+			"ratingCount": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$ratingCount", 0}}, countDelta}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"ratingAverage": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$ratingCount", 0}},
+				bson.M{"$divide": bson.A{"$ratingTotal", "$ratingCount"}},
+				0,
+			}},
+		}}},
+	}
+	result, err := m.Collection.UpdateOne(ctx, bson.M{"id": recipeID}, update)
+	if err != nil {
+		m.Logger.Error("Failed to adjust recipe rating", zap.String("recipeId", recipeID), zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrRecipeNotFound, recipeID)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type ShoppingListRepo struct {
+	Collection MongoCollection
+	Logger     *zap.Logger
+}
+
+// Ensure it implements the interface
+var _ repository.ShoppingListRepository = (*ShoppingListRepo)(nil)
+
+func (m *ShoppingListRepo) CreateShoppingList(ctx context.Context, list *entity.ShoppingList) error {
+	_, err := m.Collection.InsertOne(ctx, list)
+	if err != nil {
+		m.Logger.Error("Failed to create shopping list", zap.Error(err))
+		return err
+	}
+	m.Logger.Info("Created shopping list", zap.String("pantryId", list.PantryID), zap.String("listId", list.ID))
+	return nil
+}
+
+func (m *ShoppingListRepo) GetShoppingList(ctx context.Context, listID string) (*entity.ShoppingList, error) {
+	var list entity.ShoppingList
+	err := m.Collection.FindOne(ctx, bson.M{"id": listID}).Decode(&list)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", repository.ErrShoppingListNotFound, listID)
+		}
+		m.Logger.Error("Failed to get shopping list", zap.Error(err))
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (m *ShoppingListRepo) GetShoppingLists(ctx context.Context, pantryID string) ([]entity.ShoppingList, error) {
+	cursor, err := m.Collection.Find(ctx, bson.M{"pantryId": pantryID})
+	if err != nil {
+		m.Logger.Error("Failed to get shopping lists", zap.Error(err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	lists := []entity.ShoppingList{}
+	if err := cursor.All(ctx, &lists); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+func (m *ShoppingListRepo) AddShoppingListItem(ctx context.Context, listID string, item *entity.ShoppingListItem) error {
+	result, err := m.Collection.UpdateOne(ctx,
+		bson.M{"id": listID},
+		bson.M{"$push": bson.M{"items": item}},
+	)
+	if err != nil {
+		m.Logger.Error("Failed to add shopping list item", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrShoppingListNotFound, listID)
+	}
+	return nil
+}
+
+// SetShoppingListItemChecked only matches the item while it is in the opposite
+// state, so two clients checking the same item off concurrently cannot both
+// observe the change.
+func (m *ShoppingListRepo) SetShoppingListItemChecked(ctx context.Context, listID string, itemID string, checked bool, at time.Time) (bool, error) {
+	filter := bson.M{
+		"id":    listID,
+		"items": bson.M{"$elemMatch": bson.M{"id": itemID, "checked": !checked}},
+	}
+	update := bson.M{"$set": bson.M{"items.$.checked": true, "items.$.checkedAt": at}}
+	if !checked {
+		update = bson.M{
+			"$set":   bson.M{"items.$.checked": false},
+			"$unset": bson.M{"items.$.checkedAt": ""},
+		}
+	}
+
+	result, err := m.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		m.Logger.Error("Failed to update shopping list item", zap.Error(err))
+		return false, err
+	}
+	if result.MatchedCount() > 0 {
+		return true, nil
+	}
+
+	count, err := m.Collection.CountDocuments(ctx, bson.M{"id": listID, "items.id": itemID})
+	if err != nil {
+		return false, err
+	}
+	if count == 0 {
+		return false, m.missingListOrItem(ctx, listID, itemID)
+	}
+	return false, nil
+}
+
+func (m *ShoppingListRepo) missingListOrItem(ctx context.Context, listID string, itemID string) error {
+	count, err := m.Collection.CountDocuments(ctx, bson.M{"id": listID})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrShoppingListNotFound, listID)
+	}
+	return fmt.Errorf("%w: %s", repository.ErrShoppingItemNotFound, itemID)
+}
+
+// ClearShoppingListItems removes the checked items from a list, or every item
+// when checkedOnly is false.
+func (m *ShoppingListRepo) ClearShoppingListItems(ctx context.Context, listID string, checkedOnly bool) error {
+	update := bson.M{"$set": bson.M{"items": []entity.ShoppingListItem{}}}
+	if checkedOnly {
+		update = bson.M{"$pull": bson.M{"items": bson.M{"checked": true}}}
+	}
+	result, err := m.Collection.UpdateOne(ctx, bson.M{"id": listID}, update)
+	if err != nil {
+		m.Logger.Error("Failed to clear shopping list", zap.Error(err))
+		return err
+	}
+	if result.MatchedCount() == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrShoppingListNotFound, listID)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type SubstitutionRepo struct {
+	Collection MongoCollection
+	Logger     *zap.Logger
+}
+
+// Ensure it implements the interface
+var _ repository.SubstitutionRepository = (*SubstitutionRepo)(nil)
+
+func (m *SubstitutionRepo) GetSubstitutions(ctx context.Context, ingredientIDs []string) ([]entity.Substitution, error) {
+	filter := bson.M{"ingredientId": bson.M{"$in": ingredientIDs}}
+	opts := options.Find().SetSort(bson.D{{Key: "id", Value: 1}})
+	cursor, err := m.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		m.Logger.Error("Failed to get substitutions", zap.Strings("ingredientIds", ingredientIDs), zap.Error(err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	substitutions := []entity.Substitution{}
+	if err := cursor.All(ctx, &substitutions); err != nil {
+		return nil, err
+	}
+	return substitutions, nil
+}
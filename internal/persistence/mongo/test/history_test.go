@@ -0,0 +1,45 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/mocks"
+	mongo "github.com/thisausername99/pantry_butler/internal/persistence/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+func TestGetHistory_FiltersBySinceAndEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockCursor := mocks.NewMockMongoCursor(ctrl)
+	repo := &mongo.HistoryRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+	entryID := "entry-1"
+	filter := bson.M{
+		"pantryId":  "pantry-1",
+		"timestamp": bson.M{"$gte": since},
+		"entryId":   entryID,
+	}
+	mockCollection.EXPECT().Find(ctx, filter, gomock.Any()).Return(mockCursor, nil).Times(1)
+	mockCursor.EXPECT().All(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, results interface{}) error {
+			*results.(*[]entity.HistoryRecord) = []entity.HistoryRecord{{ID: "h1"}}
+			return nil
+		})
+	mockCursor.EXPECT().Close(ctx).Return(nil)
+
+	records, err := repo.GetHistory(ctx, "pantry-1", &since, &entryID)
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
@@ -0,0 +1,38 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/mocks"
+	mongo "github.com/thisausername99/pantry_butler/internal/persistence/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+func TestAcceptInvite_OnlyMatchesUnusedUnexpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+	repo := &mongo.InviteRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	now := time.Now()
+	filter := bson.M{
+		"code":       "code",
+		"acceptedAt": bson.M{"$exists": false},
+		"expiresAt":  bson.M{"$gt": now},
+	}
+	mockCollection.EXPECT().UpdateOne(ctx, filter, gomock.Any()).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(0))
+
+	err := repo.AcceptInvite(ctx, "code", "user-1", now)
+
+	assert.ErrorIs(t, err, repository.ErrInviteNotFound)
+}
@@ -0,0 +1,31 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/mocks"
+	mongo "github.com/thisausername99/pantry_butler/internal/persistence/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+func TestDeleteMealPlan_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockResult := mocks.NewMockMongoDeleteResult(ctrl)
+	repo := &mongo.MealPlanRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	mockCollection.EXPECT().DeleteOne(ctx, bson.M{"id": "plan-1"}).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().DeletedCount().Return(int64(0))
+
+	err := repo.DeleteMealPlan(ctx, "plan-1")
+
+	assert.ErrorIs(t, err, repository.ErrMealPlanNotFound)
+}
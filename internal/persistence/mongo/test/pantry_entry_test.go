@@ -1 +1,351 @@
 package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/mocks"
+	mongo "github.com/thisausername99/pantry_butler/internal/persistence/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+func newPantryEntryRepo(ctrl *gomock.Controller) (*mongo.PantryEntryRepo, *mocks.MockMongoCollection) {
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	return &mongo.PantryEntryRepo{Collection: mockCollection, Logger: zap.NewNop()}, mockCollection
+}
+
+func TestUpdatePantryEntry_SetsOnlySuppliedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+
+	ctx := context.Background()
+	filter := bson.M{"id": "pantry-1", "pantry_entries.id": "entry-1"}
+	update := bson.M{"$set": bson.M{
+		"pantry_entries.$.quantity":     3.5,
+		"pantry_entries.$.quantityType": "kg",
+	}}
+
+	mockCollection.EXPECT().UpdateOne(ctx, filter, update).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(1)).Times(1)
+
+	err := repo.UpdatePantryEntry(ctx, "pantry-1", "entry-1", &entity.PantryEntryPatch{
+		Quantity:     float64Ptr(3.5),
+		QuantityType: stringPtr("kg"),
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestUpdatePantryEntry_RenameSetsIngredientID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+
+	ctx := context.Background()
+	filter := bson.M{"id": "pantry-1", "pantry_entries.id": "entry-1"}
+	update := bson.M{"$set": bson.M{
+		"pantry_entries.$.name":         "Rye Flour",
+		"pantry_entries.$.ingredientId": "rye_flour",
+	}}
+
+	mockCollection.EXPECT().UpdateOne(ctx, filter, update).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(1)).Times(1)
+
+	err := repo.UpdatePantryEntry(ctx, "pantry-1", "entry-1", &entity.PantryEntryPatch{
+		Name:         stringPtr("Rye Flour"),
+		IngredientID: stringPtr("rye_flour"),
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestUpdatePantryEntry_EntryNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+
+	ctx := context.Background()
+	mockCollection.EXPECT().UpdateOne(ctx, gomock.Any(), gomock.Any()).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(0)).Times(1)
+	mockCollection.EXPECT().CountDocuments(ctx, bson.M{"id": "pantry-1"}).Return(int64(1), nil).Times(1)
+
+	err := repo.UpdatePantryEntry(ctx, "pantry-1", "missing", &entity.PantryEntryPatch{Name: stringPtr("Rye Flour")})
+
+	assert.ErrorIs(t, err, repository.ErrPantryEntryNotFound)
+}
+
+func TestUpdatePantryEntry_PantryNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+
+	ctx := context.Background()
+	mockCollection.EXPECT().UpdateOne(ctx, gomock.Any(), gomock.Any()).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(0)).Times(1)
+	mockCollection.EXPECT().CountDocuments(ctx, bson.M{"id": "missing"}).Return(int64(0), nil).Times(1)
+
+	err := repo.UpdatePantryEntry(ctx, "missing", "entry-1", &entity.PantryEntryPatch{Name: stringPtr("Rye Flour")})
+
+	assert.ErrorIs(t, err, repository.ErrPantryNotFound)
+}
+
+func TestUpdatePantryEntry_EmptyPatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, _ := newPantryEntryRepo(ctrl)
+
+	err := repo.UpdatePantryEntry(context.Background(), "pantry-1", "entry-1", &entity.PantryEntryPatch{})
+
+	assert.Error(t, err)
+}
+
+func TestConsumePantryEntry_RemovesDepletedEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoSingleResult(ctrl)
+
+	ctx := context.Background()
+	mockCollection.EXPECT().
+		FindOneAndUpdate(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, update interface{}, _ ...*options.FindOneAndUpdateOptions) mongo.MongoSingleResult {
+			// The decrement and the removal are stages of one pipeline update.
+			assert.Len(t, update, 2)
+			return mockResult
+		}).
+		Times(1)
+	// The returned pantry no longer holds the entry.
+	mockResult.EXPECT().Decode(gomock.Any()).Return(nil)
+
+	depleted, err := repo.ConsumePantryEntry(ctx, "pantry-1", "entry-1", 2)
+
+	assert.NoError(t, err)
+	assert.True(t, depleted)
+}
+
+func TestConsumePantryEntry_KeepsRemainder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoSingleResult(ctrl)
+
+	ctx := context.Background()
+	mockCollection.EXPECT().FindOneAndUpdate(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResult).Times(1)
+	mockResult.EXPECT().
+		Decode(gomock.Any()).
+		DoAndReturn(func(v interface{}) error {
+			entries := []entity.PantryEntry{{ID: "entry-1", Quantity: float64Ptr(1)}}
+			v.(*entity.Pantry).Entries = &entries
+			return nil
+		})
+
+	depleted, err := repo.ConsumePantryEntry(ctx, "pantry-1", "entry-1", 2)
+
+	assert.NoError(t, err)
+	assert.False(t, depleted)
+}
+
+func TestConsumePantryEntry_InsufficientQuantity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoSingleResult(ctrl)
+
+	ctx := context.Background()
+	mockCollection.EXPECT().FindOneAndUpdate(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResult).Times(1)
+	mockResult.EXPECT().Decode(gomock.Any()).Return(mongodriver.ErrNoDocuments)
+	mockCollection.EXPECT().
+		CountDocuments(ctx, bson.M{"id": "pantry-1", "pantry_entries.id": "entry-1"}).
+		Return(int64(1), nil)
+
+	depleted, err := repo.ConsumePantryEntry(ctx, "pantry-1", "entry-1", 10)
+
+	assert.ErrorIs(t, err, repository.ErrInsufficientQuantity)
+	assert.False(t, depleted)
+}
+
+func TestRemoveStorageLocation_UnassignsEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+
+	ctx := context.Background()
+	filter := bson.M{"id": "pantry-1", "locations.id": "fridge"}
+	update := bson.M{
+		"$pull":  bson.M{"locations": bson.M{"id": "fridge"}},
+		"$unset": bson.M{"pantry_entries.$[e].locationId": ""},
+	}
+	mockCollection.EXPECT().
+		UpdateOne(ctx, filter, update, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ interface{}, opts ...*options.UpdateOptions) (mongo.MongoUpdateResult, error) {
+			if assert.Len(t, opts, 1) && assert.NotNil(t, opts[0].ArrayFilters) {
+				assert.Equal(t, []interface{}{bson.M{"e.locationId": "fridge"}}, opts[0].ArrayFilters.Filters)
+			}
+			return mockResult, nil
+		}).
+		Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(1))
+
+	err := repo.RemoveStorageLocation(ctx, "pantry-1", "fridge")
+
+	assert.NoError(t, err)
+}
+
+func TestInsertPantryEntries_PushesEach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+
+	ctx := context.Background()
+	entries := []entity.PantryEntry{{ID: "1", Name: "milk"}, {ID: "2", Name: "eggs"}}
+	update := bson.M{"$push": bson.M{"pantry_entries": bson.M{"$each": entries}}}
+	mockCollection.EXPECT().UpdateOne(ctx, bson.M{"id": "pantry-1"}, update).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(1))
+
+	err := repo.InsertPantryEntries(ctx, "pantry-1", entries)
+
+	assert.NoError(t, err)
+}
+
+func TestIncrementPantryEntry_KeepsEarliestExpiration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+
+	ctx := context.Background()
+	expiration := time.Now()
+	filter := bson.M{"id": "pantry-1", "pantry_entries.id": "entry-1"}
+	update := bson.M{
+		"$inc": bson.M{"pantry_entries.$.quantity": 2.5},
+		"$min": bson.M{"pantry_entries.$.expiration": expiration},
+	}
+	mockCollection.EXPECT().UpdateOne(ctx, filter, update).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(1))
+
+	err := repo.IncrementPantryEntry(ctx, "pantry-1", "entry-1", 2.5, &expiration)
+
+	assert.NoError(t, err)
+}
+
+func TestDeletePantryEntry_PullsEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+
+	ctx := context.Background()
+	update := bson.M{"$pull": bson.M{"pantry_entries": bson.M{"id": "entry-1"}}}
+	mockCollection.EXPECT().UpdateOne(ctx, bson.M{"id": "pantry-1"}, update).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().ModifiedCount().Return(int64(1))
+
+	err := repo.DeletePantryEntry(ctx, "pantry-1", "entry-1")
+
+	assert.NoError(t, err)
+}
+
+func TestDeletePantryEntry_MissingEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+
+	ctx := context.Background()
+	mockCollection.EXPECT().UpdateOne(ctx, bson.M{"id": "pantry-1"}, gomock.Any()).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().ModifiedCount().Return(int64(0))
+	mockCollection.EXPECT().CountDocuments(ctx, bson.M{"id": "pantry-1"}).Return(int64(1), nil)
+
+	err := repo.DeletePantryEntry(ctx, "pantry-1", "missing")
+
+	assert.ErrorIs(t, err, repository.ErrPantryEntryNotFound)
+}
+
+func TestDeletePantry_ByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoDeleteResult(ctrl)
+
+	ctx := context.Background()
+	mockCollection.EXPECT().DeleteOne(ctx, bson.M{"id": "pantry-1"}).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().DeletedCount().Return(int64(0))
+
+	err := repo.DeletePantry(ctx, "pantry-1")
+
+	assert.ErrorIs(t, err, repository.ErrPantryNotFound)
+}
+
+func TestFindPantryEntries_MatchesPantryBeforeUnwinding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockCursor := mocks.NewMockMongoCursor(ctrl)
+
+	ctx := context.Background()
+	category := entity.CategoryDairy
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"id":                      "pantry-1",
+			"pantry_entries.category": category,
+			"pantry_entries.tags":     bson.M{"$all": []string{"organic"}},
+		}},
+		{"$unwind": "$pantry_entries"},
+		{"$replaceRoot": bson.M{"newRoot": "$pantry_entries"}},
+		{"$match": bson.M{"category": category, "tags": bson.M{"$all": []string{"organic"}}}},
+	}
+	mockCollection.EXPECT().Aggregate(ctx, pipeline).Return(mockCursor, nil).Times(1)
+	mockCursor.EXPECT().All(ctx, gomock.Any()).Return(nil)
+	mockCursor.EXPECT().Close(ctx).Return(nil)
+
+	entries, err := repo.FindPantryEntries(ctx, "pantry-1", &entity.PantryEntryFilter{Category: &category, Tags: []string{"organic"}})
+
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestAddPantryMember_SingleUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, mockCollection := newPantryEntryRepo(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+
+	ctx := context.Background()
+	member := &entity.PantryMember{UserID: "user-1", Role: entity.PantryRoleEditor}
+	mockCollection.EXPECT().UpdateOne(ctx, bson.M{"id": "pantry-1"}, gomock.Any()).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(0))
+
+	err := repo.AddPantryMember(ctx, "pantry-1", member)
+
+	assert.ErrorIs(t, err, repository.ErrPantryNotFound)
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/mocks"
+	mongo "github.com/thisausername99/pantry_butler/internal/persistence/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+func TestGetProduct_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockResult := mocks.NewMockMongoSingleResult(ctrl)
+	repo := &mongo.ProductRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	mockCollection.EXPECT().FindOne(ctx, bson.M{"code": "4006381333931"}).Return(mockResult).Times(1)
+	mockResult.EXPECT().Decode(gomock.Any()).Return(mongodriver.ErrNoDocuments)
+
+	_, err := repo.GetProduct(ctx, "4006381333931")
+
+	assert.ErrorIs(t, err, repository.ErrProductNotFound)
+}
+
+func TestUpsertProduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+	repo := &mongo.ProductRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	product := &entity.Product{Code: "4006381333931", Name: "Pencils"}
+	mockCollection.EXPECT().
+		ReplaceOne(ctx, bson.M{"code": product.Code}, product, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ interface{}, opts ...*options.ReplaceOptions) (mongo.MongoUpdateResult, error) {
+			if assert.Len(t, opts, 1) {
+				assert.True(t, *opts[0].Upsert)
+			}
+			return mockResult, nil
+		}).
+		Times(1)
+
+	err := repo.UpsertProduct(ctx, product)
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/mocks"
+	mongo "github.com/thisausername99/pantry_butler/internal/persistence/mongo"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+func TestUpsertRating_FirstRatingHasNoPrevious(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockResult := mocks.NewMockMongoSingleResult(ctrl)
+	repo := &mongo.RatingRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	mockCollection.EXPECT().FindOneAndUpdate(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResult).Times(1)
+	mockResult.EXPECT().Decode(gomock.Any()).Return(mongodriver.ErrNoDocuments).Times(1)
+
+	previous, err := repo.UpsertRating(ctx, &entity.RecipeRating{RecipeID: "recipe-1", UserID: "user-1", Stars: 4})
+
+	assert.NoError(t, err)
+	assert.Nil(t, previous)
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/mocks"
+	mongo "github.com/thisausername99/pantry_butler/internal/persistence/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+func TestUpdateRecipe_UnsetsClearedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+	repo := &mongo.RecipeRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	recipe := &entity.Recipe{
+		ID:          "recipe-1",
+		Name:        "Toast",
+		Ingredients: []entity.RecipeIngredient{{Name: "bread", IngredientID: "bread", Quantity: float64Ptr(2)}},
+		Cuisine:     stringPtr("British"),
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"name":        "Toast",
+			"description": "",
+			"ingredients": recipe.Ingredients,
+			"steps":       recipe.Steps,
+			"cuisine":     "British",
+		},
+		"$unset": bson.M{"difficulty": "", "source_url": "", "servings": "", "prep_time": "", "cook_time": ""},
+	}
+	mockCollection.EXPECT().UpdateOne(ctx, bson.M{"id": "recipe-1"}, update).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(1)).Times(1)
+
+	err := repo.UpdateRecipe(ctx, recipe)
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteRecipe_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockResult := mocks.NewMockMongoDeleteResult(ctrl)
+	repo := &mongo.RecipeRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	mockCollection.EXPECT().DeleteOne(ctx, bson.M{"id": "recipe-1"}).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().DeletedCount().Return(int64(0))
+
+	err := repo.DeleteRecipe(ctx, "recipe-1")
+
+	assert.ErrorIs(t, err, repository.ErrRecipeNotFound)
+}
+
+func TestSearchRecipes_MissingTextIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	repo := &mongo.RecipeRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	mockCollection.EXPECT().
+		Aggregate(ctx, gomock.Any()).
+		Return(nil, mongodriver.CommandError{Code: 27, Message: "text index required for $text query"}).
+		Times(1)
+
+	_, err := repo.SearchRecipes(ctx, "soup", 0, 20)
+
+	assert.ErrorIs(t, err, repository.ErrSearchUnsupported)
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/mocks"
+	mongo "github.com/thisausername99/pantry_butler/internal/persistence/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+func TestSetShoppingListItemChecked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+	repo := &mongo.ShoppingListRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	now := time.Now()
+	filter := bson.M{
+		"id":    "list-1",
+		"items": bson.M{"$elemMatch": bson.M{"id": "item-1", "checked": false}},
+	}
+	update := bson.M{"$set": bson.M{"items.$.checked": true, "items.$.checkedAt": now}}
+	mockCollection.EXPECT().UpdateOne(ctx, filter, update).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(1))
+
+	changed, err := repo.SetShoppingListItemChecked(ctx, "list-1", "item-1", true, now)
+
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
+
+func TestSetShoppingListItemChecked_AlreadyChecked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+	repo := &mongo.ShoppingListRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	mockCollection.EXPECT().UpdateOne(ctx, gomock.Any(), gomock.Any()).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(0))
+	mockCollection.EXPECT().CountDocuments(ctx, bson.M{"id": "list-1", "items.id": "item-1"}).Return(int64(1), nil)
+
+	changed, err := repo.SetShoppingListItemChecked(ctx, "list-1", "item-1", true, time.Now())
+
+	assert.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestSetShoppingListItemChecked_MissingItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockResult := mocks.NewMockMongoUpdateResult(ctrl)
+	repo := &mongo.ShoppingListRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	mockCollection.EXPECT().UpdateOne(ctx, gomock.Any(), gomock.Any()).Return(mockResult, nil).Times(1)
+	mockResult.EXPECT().MatchedCount().Return(int64(0))
+	mockCollection.EXPECT().CountDocuments(ctx, bson.M{"id": "list-1", "items.id": "item-9"}).Return(int64(0), nil)
+	mockCollection.EXPECT().CountDocuments(ctx, bson.M{"id": "list-1"}).Return(int64(1), nil)
+
+	_, err := repo.SetShoppingListItemChecked(ctx, "list-1", "item-9", true, time.Now())
+
+	assert.ErrorIs(t, err, repository.ErrShoppingItemNotFound)
+}
@@ -0,0 +1,38 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/mocks"
+	mongo "github.com/thisausername99/pantry_butler/internal/persistence/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+func TestGetSubstitutions_FiltersByIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mocks.NewMockMongoCollection(ctrl)
+	mockCursor := mocks.NewMockMongoCursor(ctrl)
+	repo := &mongo.SubstitutionRepo{Collection: mockCollection, Logger: zap.NewNop()}
+
+	ctx := context.Background()
+	filter := bson.M{"ingredientId": bson.M{"$in": []string{"butter", "buttermilk"}}}
+	mockCollection.EXPECT().Find(ctx, filter, gomock.Any()).Return(mockCursor, nil).Times(1)
+	mockCursor.EXPECT().All(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, results interface{}) error {
+			*results.(*[]entity.Substitution) = []entity.Substitution{{ID: "butter_margarine"}}
+			return nil
+		})
+	mockCursor.EXPECT().Close(ctx).Return(nil)
+
+	rules, err := repo.GetSubstitutions(ctx, []string{"butter", "buttermilk"})
+
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/units"
+	"go.uber.org/zap"
+)
+
+// cookEpsilon absorbs floating point residue from unit conversions, so an
+// ingredient drawn to the last crumb is not reported as short.
+const cookEpsilon = 1e-9
+
+// cookDraw is one deduction from a pantry entry planned by CookRecipe.
+type cookDraw struct {
+	entry *entity.PantryEntry
+	usage *entity.EntryUsage
+}
+
+// CookRecipe takes what a recipe needs out of a pantry. Quantities are scaled
+// exactly to servings when given, without the rounding ScaleRecipe applies for
+// display, so the pantry loses no more than the recipe uses. Each ingredient is drawn from the matching entries
+// that expire first, converting units where they differ. Everything on hand is
+// deducted in one transaction, so a failure leaves the pantry as it was.
+// What the pantry lacks is reported as short rather than failing the cook.
+// Optional ingredients are used when on hand but are never short, and
+// entries that do not track a quantity cannot be drawn from.
+func (u *Usecase) CookRecipe(ctx context.Context, recipeID string, pantryID string, servings *int) (*entity.CookReport, error) {
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	var recipe *entity.Recipe
+	if servings != nil {
+		var factor float64
+		recipe, factor, err = u.recipeForServings(ctx, recipeID, *servings)
+		if err == nil {
+			recipe = withIngredients(recipe, *servings, func(item entity.RecipeIngredient) entity.RecipeIngredient {
+				if item.Quantity != nil {
+					quantity := *item.Quantity * factor
+					item.Quantity = &quantity
+				}
+				return item
+			})
+		}
+	} else {
+		recipe, err = u.RepoWrapper.RecipeRepo.GetRecipe(ctx, recipeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	report, draws := planCook(recipe, pantry.EntryList())
+	if len(draws) == 0 {
+		return report, nil
+	}
+	err = u.RepoWrapper.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		// An entry drawn on by several ingredients is logged step by step.
+		current := map[string]*entity.PantryEntry{}
+		for _, draw := range draws {
+			depleted, err := u.RepoWrapper.PantryRepo.ConsumePantryEntry(ctx, pantryID, draw.entry.ID, draw.usage.Amount)
+			if err != nil {
+				return err
+			}
+			draw.usage.Depleted = depleted
+
+			before, ok := current[draw.entry.ID]
+			if !ok {
+				before = draw.entry
+			}
+			var after *entity.PantryEntry
+			if !depleted {
+				after = cloneEntry(before)
+				remaining := *before.Quantity - draw.usage.Amount
+				after.Quantity = &remaining
+			}
+			current[draw.entry.ID] = after
+			if err := u.recordHistory(ctx, pantryID, entity.HistoryActionEntryConsumed, &draw.entry.ID, before, after); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		u.Logger.Error("error cooking recipe", zap.String("recipeID", recipeID), zap.String("pantryID", pantryID), zap.Error(err))
+		return nil, err
+	}
+	return report, nil
+}
+
+// planCook works out how much of each pantry entry the recipe uses without
+// touching the pantry.
+func planCook(recipe *entity.Recipe, entries []entity.PantryEntry) (*entity.CookReport, []cookDraw) {
+	byIngredient := map[string][]*entity.PantryEntry{}
+	available := map[string]float64{}
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Quantity == nil || *entry.Quantity <= 0 {
+			continue
+		}
+		id := entryIngredientID(entry)
+		byIngredient[id] = append(byIngredient[id], entry)
+		available[entry.ID] = *entry.Quantity
+	}
+	for _, candidates := range byIngredient {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return expiresBefore(candidates[i], candidates[j])
+		})
+	}
+
+	report := &entity.CookReport{Recipe: recipe, Ingredients: []*entity.CookedIngredient{}, Complete: true}
+	var draws []cookDraw
+	for i := range recipe.Ingredients {
+		item := &recipe.Ingredients[i]
+		cooked := &entity.CookedIngredient{
+			Name:         item.Name,
+			IngredientID: recipeIngredientID(item),
+			Required:     item.Quantity,
+			Unit:         item.Unit,
+			Optional:     item.Optional,
+			Used:         []*entity.EntryUsage{},
+		}
+		report.Ingredients = append(report.Ingredients, cooked)
+		if item.Quantity == nil {
+			continue
+		}
+
+		// remaining is kept in the recipe's unit and need in the entry's.
+		remaining := *item.Quantity
+		for _, entry := range byIngredient[cooked.IngredientID] {
+			left := available[entry.ID]
+			if remaining <= cookEpsilon {
+				break
+			}
+			if left <= cookEpsilon {
+				continue
+			}
+			need, err := units.ConvertAmount(remaining, stringValue(item.Unit), stringValue(entry.QuantityType), entry.Name)
+			if err != nil {
+				continue
+			}
+			take := need
+			if left < need {
+				take = left
+			}
+			available[entry.ID] = left - take
+			remaining -= remaining * take / need
+
+			usage := &entity.EntryUsage{EntryID: entry.ID, Name: entry.Name, Amount: take, QuantityType: entry.QuantityType}
+			cooked.Used = append(cooked.Used, usage)
+			draws = append(draws, cookDraw{entry: entry, usage: usage})
+		}
+		if remaining > cookEpsilon && !item.Optional {
+			cooked.Short = remaining
+			report.Complete = false
+		}
+	}
+	return report, draws
+}
@@ -0,0 +1,8 @@
+package usecase
+
+import "errors"
+
+var (
+	ErrUnauthenticated = errors.New("caller is not authenticated")
+	ErrForbidden       = errors.New("caller does not have access to this pantry")
+)
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV      ExportFormat = "csv"
+	ExportFormatJSON     ExportFormat = "json"
+	ExportFormatMarkdown ExportFormat = "markdown"
+)
+
+// ParseExportFormat accepts csv, json, markdown or md, in any case.
+func ParseExportFormat(value string) (ExportFormat, error) {
+	switch strings.ToLower(value) {
+	case "csv":
+		return ExportFormatCSV, nil
+	case "json":
+		return ExportFormatJSON, nil
+	case "markdown", "md":
+		return ExportFormatMarkdown, nil
+	}
+	return "", fmt.Errorf("unsupported export format %q; use csv, json or markdown", value)
+}
+
+// PantryExport is a loaded pantry ready to be written out. It is split from
+// the loading step so that access errors surface before any output is sent.
+type PantryExport struct {
+	Format  ExportFormat
+	pantry  *entity.Pantry
+	entries []entity.PantryEntry
+}
+
+// ExportPantry loads a pantry and its entries for export.
+func (u *Usecase) ExportPantry(ctx context.Context, pantryID string, format ExportFormat) (*PantryExport, error) {
+	pantry, entries, err := u.getPantryWithEntries(ctx, pantryID)
+	if err != nil {
+		return nil, err
+	}
+	return &PantryExport{Format: format, pantry: pantry, entries: entries}, nil
+}
+
+func (e *PantryExport) ContentType() string {
+	switch e.Format {
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case ExportFormatJSON:
+		return "application/json"
+	}
+	return "text/markdown; charset=utf-8"
+}
+
+func (e *PantryExport) Filename() string {
+	extension := string(e.Format)
+	if e.Format == ExportFormatMarkdown {
+		extension = "md"
+	}
+	return fmt.Sprintf("pantry-%s.%s", e.pantry.ID, extension)
+}
+
+// Write streams the export to w entry by entry.
+func (e *PantryExport) Write(w io.Writer) error {
+	switch e.Format {
+	case ExportFormatCSV:
+		return e.writeCSV(w)
+	case ExportFormatJSON:
+		return e.writeJSON(w)
+	case ExportFormatMarkdown:
+		return e.writeMarkdown(w)
+	}
+	return fmt.Errorf("unsupported export format %q", e.Format)
+}
+
+// writeCSV uses the same columns ImportPantryEntries reads, plus the entry
+// ID, so an export can be imported into another pantry.
+func (e *PantryExport) writeCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"id", "name", "quantity", "unit", "expiration", "location", "category", "tags"}); err != nil {
+		return err
+	}
+	for i := range e.entries {
+		entry := &e.entries[i]
+		record := []string{
+			entry.ID,
+			entry.Name,
+			"",
+			stringValue(entry.QuantityType),
+			"",
+			e.locationName(entry),
+			"",
+			strings.Join(entry.Tags, ";"),
+		}
+		if entry.Quantity != nil {
+			record[2] = strconv.FormatFloat(*entry.Quantity, 'f', -1, 64)
+		}
+		if entry.Expiration != nil {
+			record[4] = entry.Expiration.Format(time.RFC3339)
+		}
+		if entry.Category != nil {
+			record[6] = entry.Category.String()
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeJSON writes the pantry with its entries as one object, encoding the
+// entries one at a time rather than marshalling the whole document.
+func (e *PantryExport) writeJSON(w io.Writer) error {
+	header, err := json.Marshal(struct {
+		ID        string                   `json:"id"`
+		Name      string                   `json:"name"`
+		Locations []entity.StorageLocation `json:"locations"`
+	}{e.pantry.ID, e.pantry.Name, e.pantry.Locations})
+	if err != nil {
+		return err
+	}
+	// Reopen the header object to append the entries array to it
+	if _, err := fmt.Fprintf(w, `%s,"entries":[`, header[:len(header)-1]); err != nil {
+		return err
+	}
+	for i := range e.entries {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		entry, err := json.Marshal(&e.entries[i])
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(entry); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "]}\n")
+	return err
+}
+
+// writeMarkdown writes a printable checklist grouped by storage location.
+func (e *PantryExport) writeMarkdown(w io.Writer) error {
+	title := e.pantry.Name
+	if title == "" {
+		title = "Pantry"
+	}
+	buf := bufio.NewWriter(w)
+	fmt.Fprintf(buf, "# %s\n", title)
+	for _, group := range groupByLocation(e.pantry, e.entries) {
+		if len(group.Entries) == 0 {
+			continue
+		}
+		heading := "Unassigned"
+		if group.Location != nil {
+			heading = group.Location.Name
+		}
+		fmt.Fprintf(buf, "\n## %s\n\n", heading)
+		for _, entry := range group.Entries {
+			fmt.Fprintf(buf, "- [ ] %s", entry.Name)
+			if entry.Quantity != nil {
+				fmt.Fprintf(buf, " — %s", strconv.FormatFloat(*entry.Quantity, 'f', -1, 64))
+				if entry.QuantityType != nil {
+					fmt.Fprintf(buf, " %s", *entry.QuantityType)
+				}
+			}
+			if entry.Expiration != nil {
+				fmt.Fprintf(buf, " (expires %s)", entry.Expiration.Format("2006-01-02"))
+			}
+			buf.WriteString("\n")
+		}
+	}
+	return buf.Flush()
+}
+
+func (e *PantryExport) locationName(entry *entity.PantryEntry) string {
+	if entry.LocationID == nil {
+		return ""
+	}
+	if location := e.pantry.Location(*entry.LocationID); location != nil {
+		return location.Name
+	}
+	return ""
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
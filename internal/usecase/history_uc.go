@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// requestID returns the ID the HTTP RequestContext middleware assigned to the
+// current request, if any.
+func requestID(ctx context.Context) *string {
+	id, ok := ctx.Value("requestID").(string)
+	if !ok || id == "" {
+		return nil
+	}
+	return &id
+}
+
+// GetPantryHistory lists a pantry's history, newest first. A deleted pantry
+// has no members left to check, so its history stays readable by anyone
+// recorded as acting on it, which includes the owner who deleted it.
+func (u *Usecase) GetPantryHistory(ctx context.Context, pantryID string, since *time.Time, entryID *string) ([]entity.HistoryRecord, error) {
+	_, err := u.authorize(ctx, pantryID, entity.PantryRoleViewer)
+	if errors.Is(err, repository.ErrPantryNotFound) {
+		return u.getDeletedPantryHistory(ctx, pantryID, since, entryID, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u.RepoWrapper.HistoryRepo.GetHistory(ctx, pantryID, since, entryID)
+}
+
+// getDeletedPantryHistory returns the history of a pantry that no longer
+// exists if the caller appears in it as an actor. notFound is returned when
+// there is no history either.
+func (u *Usecase) getDeletedPantryHistory(ctx context.Context, pantryID string, since *time.Time, entryID *string, notFound error) ([]entity.HistoryRecord, error) {
+	records, err := u.RepoWrapper.HistoryRepo.GetHistory(ctx, pantryID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, notFound
+	}
+	userID := callerID(ctx)
+	if !slices.ContainsFunc(records, func(record entity.HistoryRecord) bool { return record.ActorID == userID }) {
+		return nil, ErrForbidden
+	}
+
+	// Apply the same filters HistoryRepository.GetHistory would.
+	filtered := []entity.HistoryRecord{}
+	for _, record := range records {
+		if since != nil && record.Timestamp.Before(*since) {
+			continue
+		}
+		if entryID != nil && (record.EntryID == nil || *record.EntryID != *entryID) {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	return filtered, nil
+}
+
+// recordHistory appends an audit record for a change. Changes made in a
+// transaction are recorded inside it, so a failure to record rolls the change
+// back instead of leaving it out of the history.
+func (u *Usecase) recordHistory(ctx context.Context, pantryID string, action entity.HistoryAction, entryID *string, before *entity.PantryEntry, after *entity.PantryEntry) error {
+	record := &entity.HistoryRecord{
+		ID:        uuid.New().String(),
+		PantryID:  pantryID,
+		EntryID:   entryID,
+		Action:    action,
+		ActorID:   callerID(ctx),
+		RequestID: requestID(ctx),
+		Before:    before,
+		After:     after,
+		Timestamp: time.Now(),
+	}
+	if err := u.RepoWrapper.HistoryRepo.AppendHistory(ctx, record); err != nil {
+		u.Logger.Error("error recording pantry history",
+			zap.String("pantryID", pantryID),
+			zap.String("action", action.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// recordEntryChange records an update to an entry. before is the entry as
+// loaded with the pantry; change applies the mutation to a copy of it to
+// produce the after value. Nothing is recorded about the entry's values when
+// the pantry was loaded without it.
+func (u *Usecase) recordEntryChange(ctx context.Context, pantry *entity.Pantry, entryID string, action entity.HistoryAction, change func(entry *entity.PantryEntry) *entity.PantryEntry) error {
+	before := pantry.Entry(entryID)
+	var after *entity.PantryEntry
+	if before != nil {
+		after = change(cloneEntry(before))
+	}
+	return u.recordHistory(ctx, pantry.ID, action, &entryID, before, after)
+}
+
+// cloneEntry copies an entry deeply enough that the copy can be changed
+// without affecting the original.
+func cloneEntry(entry *entity.PantryEntry) *entity.PantryEntry {
+	clone := *entry
+	if entry.Tags != nil {
+		clone.Tags = append([]string{}, entry.Tags...)
+	}
+	return &clone
+}
+
+// applyPatch mirrors what PantryRepository.UpdatePantryEntry stores.
+func applyPatch(entry *entity.PantryEntry, patch *entity.PantryEntryPatch) *entity.PantryEntry {
+	if patch.Name != nil {
+		entry.Name = *patch.Name
+	}
+	if patch.IngredientID != nil {
+		entry.IngredientID = *patch.IngredientID
+	}
+	if patch.Quantity != nil {
+		entry.Quantity = patch.Quantity
+	}
+	if patch.QuantityType != nil {
+		entry.QuantityType = patch.QuantityType
+	}
+	if patch.Expiration != nil {
+		entry.Expiration = patch.Expiration
+	}
+	if patch.Category != nil {
+		entry.Category = patch.Category
+	}
+	if patch.Tags != nil {
+		entry.Tags = patch.Tags
+	}
+	return entry
+}
@@ -0,0 +1,295 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+// maxImportRows keeps a single import well inside MongoDB's document size
+// limit, since every entry is pushed into the one pantry document.
+// maxImportSize bounds how much of an upload is read to find those rows.
+const (
+	maxImportRows = 1000
+	maxImportSize = 4 << 20
+)
+
+var (
+	errTooManyImportRows = fmt.Errorf("an import can contain at most %d rows", maxImportRows)
+	errImportTooLarge    = fmt.Errorf("an import file can be at most %d MiB", maxImportSize>>20)
+)
+
+// importRow is one parsed row of an import file. Either input or err is set.
+type importRow struct {
+	row   int
+	input *entity.PantryEntryInput
+	err   error
+}
+
+// ImportFormatFromFilename picks the import format from a file's extension.
+func ImportFormatFromFilename(name string) (entity.ImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return entity.ImportFormatCSV, nil
+	case ".json":
+		return entity.ImportFormatJSON, nil
+	}
+	return "", fmt.Errorf("cannot tell the import format of %q; use a .csv or .json file", name)
+}
+
+// ImportPantryEntries adds many entries to a pantry from a CSV or JSON file.
+// Every row is validated with the same rules as a single insert. If any row
+// fails, nothing is written and the failures are reported; otherwise all rows
+// are stored in one write. A dry run validates and reports without writing.
+func (u *Usecase) ImportPantryEntries(ctx context.Context, pantryID string, r io.Reader, format entity.ImportFormat, dryRun bool) (*entity.EntryImportResult, error) {
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	r = &importReader{r: r, remaining: maxImportSize}
+	var rows []importRow
+	switch format {
+	case entity.ImportFormatCSV:
+		rows, err = parseEntryCSV(r, pantry)
+	case entity.ImportFormatJSON:
+		rows, err = parseEntryJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.EntryImportResult{DryRun: dryRun, Errors: []entity.ImportRowError{}}
+	now := time.Now()
+	entries := make([]entity.PantryEntry, 0, len(rows))
+	for _, row := range rows {
+		err := row.err
+		if err == nil {
+			var entry *entity.PantryEntry
+			entry, err = buildPantryEntry(pantry, row.input, now)
+			if err == nil {
+				entries = append(entries, *entry)
+			}
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, entity.ImportRowError{Row: row.row, Message: err.Error()})
+		}
+	}
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("import file contains no entries")
+	}
+
+	if err := u.RepoWrapper.PantryRepo.InsertPantryEntries(ctx, pantryID, entries); err != nil {
+		u.Logger.Error("error importing pantry entries", zap.String("pantryID", pantryID), zap.Error(err))
+		return nil, err
+	}
+	for i := range entries {
+		if err := u.recordHistory(ctx, pantryID, entity.HistoryActionEntryInserted, &entries[i].ID, nil, &entries[i]); err != nil {
+			return nil, err
+		}
+	}
+	result.Imported = len(entries)
+	return result, nil
+}
+
+// importReader reads an upload, failing with errImportTooLarge once more than
+// remaining bytes are read rather than silently truncating as io.LimitReader
+// would.
+type importReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *importReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, errImportTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n - 1, errImportTooLarge
+	}
+	return n, err
+}
+
+// parseEntryCSV reads entries from CSV with a header row. Only name is
+// required; the other columns are quantity, unit, expiration (YYYY-MM-DD or
+// RFC 3339), location (ID or name), category and tags (separated by ";").
+// Reading stops with an error at the first row past maxImportRows.
+func parseEntryCSV(r io.Reader, pantry *entity.Pantry) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("import CSV is empty")
+		}
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New(`import CSV is missing the "name" column`)
+	}
+
+	var rows []importRow
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if row > maxImportRows {
+			return nil, errTooManyImportRows
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, importRow{row: row, err: err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		input, err := parseEntryRecord(record, columns, pantry)
+		rows = append(rows, importRow{row: row, input: input, err: err})
+	}
+	return rows, nil
+}
+
+func parseEntryRecord(record []string, columns map[string]int, pantry *entity.Pantry) (*entity.PantryEntryInput, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	input := &entity.PantryEntryInput{Name: field("name")}
+	if quantity := field("quantity"); quantity != "" {
+		value, err := strconv.ParseFloat(quantity, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q", quantity)
+		}
+		input.Quantity = &value
+	}
+	if unit := field("unit"); unit != "" {
+		input.QuantityType = &unit
+	}
+	if expiration := field("expiration"); expiration != "" {
+		value, err := parseImportDate(expiration)
+		if err != nil {
+			return nil, err
+		}
+		input.Expiration = &value
+	}
+	if location := field("location"); location != "" {
+		id := resolveLocationRef(pantry, location)
+		input.LocationID = &id
+	}
+	if name := field("category"); name != "" {
+		input.Category = importCategory(name)
+	}
+	if tags := field("tags"); tags != "" {
+		input.Tags = strings.Split(tags, ";")
+	}
+	return input, nil
+}
+
+// parseEntryJSON reads a JSON array of PantryEntryInput objects. Elements are
+// decoded one at a time so a malformed element only fails its own row, and
+// reading stops with an error at the first element past maxImportRows.
+func parseEntryJSON(r io.Reader) ([]importRow, error) {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, importJSONError(err)
+	}
+
+	var rows []importRow
+	for row := 1; decoder.More(); row++ {
+		if row > maxImportRows {
+			return nil, errTooManyImportRows
+		}
+		var element json.RawMessage
+		if err := decoder.Decode(&element); err != nil {
+			return nil, importJSONError(err)
+		}
+		var input entity.PantryEntryInput
+		if err := json.Unmarshal(element, &input); err != nil {
+			rows = append(rows, importRow{row: row, err: err})
+			continue
+		}
+		if input.Category != nil {
+			input.Category = importCategory(string(*input.Category))
+		}
+		rows = append(rows, importRow{row: row, input: &input})
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, importJSONError(err)
+	}
+	return rows, nil
+}
+
+// importJSONError explains a failure to read the import JSON as an array.
+func importJSONError(err error) error {
+	if errors.Is(err, errImportTooLarge) {
+		return err
+	}
+	if err == nil {
+		return errors.New("import JSON must be an array of entries")
+	}
+	return fmt.Errorf("import JSON must be an array of entries: %w", err)
+}
+
+// importCategory reads a category name in any case, as both import formats
+// accept "dairy" as well as "DAIRY".
+func importCategory(name string) *entity.Category {
+	category := entity.Category(strings.ToUpper(strings.TrimSpace(name)))
+	return &category
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiration %q; use YYYY-MM-DD", value)
+	}
+	return t, nil
+}
+
+// resolveLocationRef maps a location given by ID or by name to its ID. An
+// unknown reference is returned unchanged so validation reports it.
+func resolveLocationRef(pantry *entity.Pantry, ref string) string {
+	if pantry.Location(ref) != nil {
+		return ref
+	}
+	for _, location := range pantry.Locations {
+		if strings.EqualFold(location.Name, ref) {
+			return location.ID
+		}
+	}
+	return ref
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// defaultLocations returns the storage locations every new pantry starts with.
+func defaultLocations() []entity.StorageLocation {
+	return []entity.StorageLocation{
+		{ID: uuid.New().String(), Name: "Shelf", Kind: entity.LocationKindShelf},
+		{ID: uuid.New().String(), Name: "Fridge", Kind: entity.LocationKindFridge},
+		{ID: uuid.New().String(), Name: "Freezer", Kind: entity.LocationKindFreezer},
+	}
+}
+
+func (u *Usecase) GetPantryLocations(ctx context.Context, pantryID string) ([]entity.StorageLocation, error) {
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	if pantry.Locations == nil {
+		return []entity.StorageLocation{}, nil
+	}
+	return pantry.Locations, nil
+}
+
+func (u *Usecase) AddStorageLocation(ctx context.Context, pantryID string, input *entity.StorageLocationInput) (*entity.StorageLocation, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.New("location name is required")
+	}
+	if !input.Kind.IsValid() {
+		return nil, fmt.Errorf("invalid location kind %s", input.Kind)
+	}
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range pantry.Locations {
+		if strings.EqualFold(existing.Name, name) {
+			return nil, fmt.Errorf("location %q already exists", name)
+		}
+	}
+
+	location := &entity.StorageLocation{ID: uuid.New().String(), Name: name, Kind: input.Kind}
+	if err := u.RepoWrapper.PantryRepo.AddStorageLocation(ctx, pantryID, location); err != nil {
+		u.Logger.Error("error adding storage location", zap.Error(err))
+		return nil, err
+	}
+	return location, nil
+}
+
+// RemoveStorageLocation deletes a location; entries stored there become
+// unassigned rather than being deleted, and each is recorded as updated.
+func (u *Usecase) RemoveStorageLocation(ctx context.Context, pantryID string, locationID string) error {
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return err
+	}
+	if err := u.RepoWrapper.PantryRepo.RemoveStorageLocation(ctx, pantryID, locationID); err != nil {
+		return err
+	}
+	if pantry.Entries == nil {
+		return nil
+	}
+	for _, entry := range *pantry.Entries {
+		if entry.LocationID == nil || *entry.LocationID != locationID {
+			continue
+		}
+		err := u.recordEntryChange(ctx, pantry, entry.ID, entity.HistoryActionEntryUpdated, func(entry *entity.PantryEntry) *entity.PantryEntry {
+			entry.LocationID = nil
+			return entry
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *Usecase) MovePantryEntry(ctx context.Context, pantryID string, entryID string, locationID string) error {
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return err
+	}
+	if pantry.Location(locationID) == nil {
+		return fmt.Errorf("%w: %s", repository.ErrLocationNotFound, locationID)
+	}
+	if err := u.RepoWrapper.PantryRepo.MovePantryEntry(ctx, pantryID, entryID, locationID); err != nil {
+		return err
+	}
+	return u.recordEntryChange(ctx, pantry, entryID, entity.HistoryActionEntryUpdated, func(entry *entity.PantryEntry) *entity.PantryEntry {
+		entry.LocationID = &locationID
+		return entry
+	})
+}
+
+// GetPantryEntriesByLocation groups a pantry's entries by storage location,
+// in the order the pantry defines its locations. Entries without a location,
+// or whose location no longer exists, are collected in a final group with no
+// location.
+func (u *Usecase) GetPantryEntriesByLocation(ctx context.Context, pantryID string) ([]entity.LocationGroup, error) {
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return groupByLocation(pantry, pantry.EntryList()), nil
+}
+
+func groupByLocation(pantry *entity.Pantry, entries []entity.PantryEntry) []entity.LocationGroup {
+	groups := make([]entity.LocationGroup, len(pantry.Locations))
+	index := make(map[string]int, len(pantry.Locations))
+	for i := range pantry.Locations {
+		groups[i] = entity.LocationGroup{Location: &pantry.Locations[i], Entries: []*entity.PantryEntry{}}
+		index[pantry.Locations[i].ID] = i
+	}
+	unassigned := entity.LocationGroup{Entries: []*entity.PantryEntry{}}
+	for i := range entries {
+		entry := &entries[i]
+		if entry.LocationID != nil {
+			if idx, ok := index[*entry.LocationID]; ok {
+				groups[idx].Entries = append(groups[idx].Entries, entry)
+				continue
+			}
+		}
+		unassigned.Entries = append(unassigned.Entries, entry)
+	}
+	if len(unassigned.Entries) > 0 {
+		groups = append(groups, unassigned)
+	}
+	return groups
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+)
+
+// maxMealPlanRange bounds how many days a single calendar query may span.
+const maxMealPlanRange = 366
+
+// PlanMeal schedules a recipe for a meal slot on the day containing
+// input.Date.
+func (u *Usecase) PlanMeal(ctx context.Context, pantryID string, input *entity.MealPlanInput) (*entity.MealPlan, error) {
+	if !input.Slot.IsValid() {
+		return nil, errors.New("invalid meal slot")
+	}
+	if input.Servings != nil && *input.Servings <= 0 {
+		return nil, errors.New("servings must be positive")
+	}
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	recipe, err := u.RepoWrapper.RecipeRepo.GetRecipe(ctx, input.RecipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &entity.MealPlan{
+		ID:        uuid.New().String(),
+		PantryID:  pantryID,
+		Date:      planDay(input.Date),
+		Slot:      input.Slot,
+		RecipeID:  input.RecipeID,
+		Servings:  input.Servings,
+		Note:      input.Note,
+		CreatedBy: callerID(ctx),
+		CreatedAt: time.Now(),
+	}
+	if err := u.RepoWrapper.MealPlanRepo.CreateMealPlan(ctx, plan); err != nil {
+		return nil, err
+	}
+	plan.Recipe = recipe
+	plan.MissingIngredients = missingIngredients(recipe, pantryIngredients(pantry.EntryList()))
+	return plan, nil
+}
+
+// GetMealPlans lists the meals planned in a pantry between two days,
+// inclusive. Each plan comes with its recipe and the ingredients the pantry
+// lacks, loaded once for the whole range.
+func (u *Usecase) GetMealPlans(ctx context.Context, pantryID string, from time.Time, to time.Time) ([]entity.MealPlan, error) {
+	from, to = planDay(from), planDay(to)
+	if to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+	if to.Sub(from) > maxMealPlanRange*24*time.Hour {
+		return nil, errors.New("date range cannot exceed one year")
+	}
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := u.RepoWrapper.MealPlanRepo.GetMealPlans(ctx, pantryID, from, to)
+	if err != nil || len(plans) == 0 {
+		return plans, err
+	}
+
+	recipeIDs := make([]string, 0, len(plans))
+	seen := make(map[string]bool, len(plans))
+	for _, plan := range plans {
+		if !seen[plan.RecipeID] {
+			seen[plan.RecipeID] = true
+			recipeIDs = append(recipeIDs, plan.RecipeID)
+		}
+	}
+	recipes, err := u.RepoWrapper.RecipeRepo.GetRecipesByIDs(ctx, recipeIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Recipe, len(recipes))
+	for i := range recipes {
+		byID[recipes[i].ID] = &recipes[i]
+	}
+
+	available := pantryIngredients(pantry.EntryList())
+	for i := range plans {
+		recipe, ok := byID[plans[i].RecipeID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", repository.ErrRecipeNotFound, plans[i].RecipeID)
+		}
+		plans[i].Recipe = recipe
+		plans[i].MissingIngredients = missingIngredients(recipe, available)
+	}
+	return plans, nil
+}
+
+func (u *Usecase) RemoveMealPlan(ctx context.Context, planID string) error {
+	plan, err := u.RepoWrapper.MealPlanRepo.GetMealPlan(ctx, planID)
+	if err != nil {
+		return err
+	}
+	if _, err := u.authorize(ctx, plan.PantryID, entity.PantryRoleEditor); err != nil {
+		return err
+	}
+	return u.RepoWrapper.MealPlanRepo.DeleteMealPlan(ctx, planID)
+}
+
+// planDay truncates a time to midnight UTC of its calendar day.
+func planDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+const inviteTTL = 7 * 24 * time.Hour
+
+// callerID returns the user making the request, as placed on the context by
+// the HTTP RequestContext middleware from the X-User-ID header.
+func callerID(ctx context.Context) string {
+	userID, _ := ctx.Value("userID").(string)
+	return userID
+}
+
+// authorize loads the pantry and checks that the caller holds at least the
+// required role in it.
+func (u *Usecase) authorize(ctx context.Context, pantryID string, required entity.PantryRole) (*entity.Pantry, error) {
+	userID := callerID(ctx)
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+	pantry, err := u.RepoWrapper.PantryRepo.GetPantry(ctx, pantryID)
+	if err != nil {
+		return nil, err
+	}
+	role, ok := pantry.RoleOf(userID)
+	if !ok || !role.Allows(required) {
+		u.Logger.Warn("pantry access denied",
+			zap.String("pantryID", pantryID),
+			zap.String("userID", userID),
+			zap.String("required", required.String()),
+		)
+		return nil, ErrForbidden
+	}
+	return pantry, nil
+}
+
+func (u *Usecase) GetPantryMembers(ctx context.Context, pantryID string) ([]entity.PantryMember, error) {
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	if len(pantry.Members) == 0 && pantry.OwnerID != "" {
+		return []entity.PantryMember{{UserID: pantry.OwnerID, Role: entity.PantryRoleOwner, AddedAt: pantry.CreatedAt}}, nil
+	}
+	return pantry.Members, nil
+}
+
+// InvitePantryMember creates an invite code that lets the holder of the given
+// email address join the pantry with the given role. Only owners can invite,
+// and ownership itself cannot be granted by invite.
+func (u *Usecase) InvitePantryMember(ctx context.Context, pantryID string, email string, role entity.PantryRole) (*entity.PantryInvite, error) {
+	if !role.IsValid() || role == entity.PantryRoleOwner {
+		return nil, fmt.Errorf("cannot invite with role %s", role)
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil, errors.New("email is required")
+	}
+	if _, err := u.authorize(ctx, pantryID, entity.PantryRoleOwner); err != nil {
+		return nil, err
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	invite := &entity.PantryInvite{
+		Code:      code,
+		PantryID:  pantryID,
+		Email:     email,
+		Role:      role,
+		InvitedBy: callerID(ctx),
+		CreatedAt: now,
+		ExpiresAt: now.Add(inviteTTL),
+	}
+	if err := u.RepoWrapper.InviteRepo.CreateInvite(ctx, invite); err != nil {
+		u.Logger.Error("error creating pantry invite", zap.Error(err))
+		return nil, err
+	}
+	return invite, nil
+}
+
+// AcceptPantryInvite adds the caller to the invited pantry. The caller's
+// email must match the one the invite was issued to. Consuming the invite and
+// granting the membership happen in one transaction, so a failure leaves the
+// invite usable.
+func (u *Usecase) AcceptPantryInvite(ctx context.Context, code string) error {
+	userID := callerID(ctx)
+	if userID == "" {
+		return ErrUnauthenticated
+	}
+	invite, err := u.RepoWrapper.InviteRepo.GetInvite(ctx, code)
+	if err != nil {
+		return err
+	}
+	user, err := u.RepoWrapper.UserRepo.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(user.Email, invite.Email) {
+		return ErrForbidden
+	}
+
+	return u.RepoWrapper.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		pantry, err := u.RepoWrapper.PantryRepo.GetPantry(ctx, invite.PantryID)
+		if err != nil {
+			return err
+		}
+		if _, ok := pantry.RoleOf(userID); ok {
+			return fmt.Errorf("user %s is already a member of pantry %s", userID, invite.PantryID)
+		}
+
+		now := time.Now()
+		if err := u.RepoWrapper.InviteRepo.AcceptInvite(ctx, code, userID, now); err != nil {
+			return err
+		}
+		member := &entity.PantryMember{UserID: userID, Role: invite.Role, AddedAt: now}
+		if err := u.RepoWrapper.PantryRepo.AddPantryMember(ctx, invite.PantryID, member); err != nil {
+			u.Logger.Error("error adding pantry member", zap.Error(err))
+			return err
+		}
+		return u.RepoWrapper.UserRepo.UpdateUserWithPantry(ctx, userID, invite.PantryID)
+	})
+}
+
+// RevokePantryAccess removes a member from the pantry. Owners can revoke
+// anyone but themselves; any member can revoke their own access to leave.
+func (u *Usecase) RevokePantryAccess(ctx context.Context, pantryID string, userID string) error {
+	required := entity.PantryRoleOwner
+	if userID == callerID(ctx) {
+		required = entity.PantryRoleViewer
+	}
+	pantry, err := u.authorize(ctx, pantryID, required)
+	if err != nil {
+		return err
+	}
+	if role, ok := pantry.RoleOf(userID); !ok {
+		return fmt.Errorf("user %s is not a member of pantry %s", userID, pantryID)
+	} else if role == entity.PantryRoleOwner {
+		return errors.New("the pantry owner cannot be revoked")
+	}
+
+	if err := u.RepoWrapper.PantryRepo.RemovePantryMember(ctx, pantryID, userID); err != nil {
+		u.Logger.Error("error removing pantry member", zap.Error(err))
+		return err
+	}
+	return u.RepoWrapper.UserRepo.DeletePantryFromUser(ctx, userID, pantryID)
+}
+
+func newInviteCode() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/units"
+)
+
+// InsertOrMergePantryEntry adds stock to a pantry. When the pantry already
+// holds an entry for the same ingredient in the same location, or also with
+// no location when none is given, and a quantity in a compatible unit, that
+// entry's quantity is increased instead of a second entry being created. The
+// pantry is read and written in one transaction, so two concurrent additions
+// cannot both pick the same target from a stale read. It reports whether the
+// input was merged.
+func (u *Usecase) InsertOrMergePantryEntry(ctx context.Context, pantryID string, input *entity.PantryEntryInput) (*entity.PantryEntry, bool, error) {
+	var entry, target, merged *entity.PantryEntry
+	err := u.RepoWrapper.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+		if err != nil {
+			return err
+		}
+		entry, err = buildPantryEntry(pantry, input, time.Now())
+		if err != nil {
+			return err
+		}
+
+		var amount float64
+		target, amount = findMergeTarget(pantry, entry)
+		merged = nil
+		if target == nil {
+			return u.storePantryEntry(ctx, pantryID, entry)
+		}
+		if err := u.RepoWrapper.PantryRepo.IncrementPantryEntry(ctx, pantryID, target.ID, amount, entry.Expiration); err != nil {
+			return err
+		}
+		merged = cloneEntry(target)
+		total := *target.Quantity + amount
+		merged.Quantity = &total
+		merged.Expiration = earliest(target.Expiration, entry.Expiration)
+		return u.recordHistory(ctx, pantryID, entity.HistoryActionEntryUpdated, &target.ID, target, merged)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if merged == nil {
+		return entry, false, nil
+	}
+	return merged, true, nil
+}
+
+// findMergeTarget returns the existing entry in the new entry's location that
+// the new one can be merged into, and the new entry's quantity converted to
+// that entry's unit.
+func findMergeTarget(pantry *entity.Pantry, entry *entity.PantryEntry) (*entity.PantryEntry, float64) {
+	if entry.Quantity == nil || pantry.Entries == nil {
+		return nil, 0
+	}
+	id := entryIngredientID(entry)
+	for i := range *pantry.Entries {
+		candidate := &(*pantry.Entries)[i]
+		if candidate.Quantity == nil || entryIngredientID(candidate) != id {
+			continue
+		}
+		if !sameLocation(candidate.LocationID, entry.LocationID) {
+			continue
+		}
+		amount, err := units.ConvertAmount(*entry.Quantity, stringValue(entry.QuantityType), stringValue(candidate.QuantityType), entry.Name)
+		if err != nil {
+			continue
+		}
+		return candidate, amount
+	}
+	return nil, 0
+}
+
+// MergeDuplicateEntries consolidates entries that share an ingredient and
+// location and whose quantities are in compatible units. The first such entry
+// in the pantry is kept and takes the combined quantity, the earliest
+// expiration and the union of the tags; the others are removed. The pantry is
+// read and rewritten in one transaction, so a change made to it in between
+// aborts and retries the merge instead of being overwritten.
+func (u *Usecase) MergeDuplicateEntries(ctx context.Context, pantryID string) (*entity.MergeReport, error) {
+	var clusters []*duplicateCluster
+	err := u.RepoWrapper.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+		if err != nil {
+			return err
+		}
+		clusters = nil
+		for _, cluster := range clusterDuplicates(pantry) {
+			if len(cluster.merged) == 0 {
+				continue
+			}
+			if err := u.RepoWrapper.PantryRepo.MergePantryEntries(ctx, pantryID, cluster.keep, cluster.mergedIDs()); err != nil {
+				return err
+			}
+			if err := u.recordHistory(ctx, pantryID, entity.HistoryActionEntryUpdated, &cluster.keep.ID, cluster.original, cluster.keep); err != nil {
+				return err
+			}
+			for _, entry := range cluster.merged {
+				if err := u.recordHistory(ctx, pantryID, entity.HistoryActionEntryDeleted, &entry.ID, entry, nil); err != nil {
+					return err
+				}
+			}
+			clusters = append(clusters, cluster)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.MergeReport{Groups: []*entity.MergedEntryGroup{}}
+	for _, cluster := range clusters {
+		removeIDs := cluster.mergedIDs()
+		report.Groups = append(report.Groups, &entity.MergedEntryGroup{Entry: cluster.keep, MergedEntryIDs: removeIDs})
+		report.Removed += len(removeIDs)
+	}
+	return report, nil
+}
+
+// duplicateCluster is a kept entry and the duplicates folded into it.
+type duplicateCluster struct {
+	original *entity.PantryEntry
+	keep     *entity.PantryEntry
+	merged   []*entity.PantryEntry
+}
+
+// mergedIDs lists the IDs of the entries folded into the kept one.
+func (c *duplicateCluster) mergedIDs() []string {
+	ids := make([]string, len(c.merged))
+	for i, entry := range c.merged {
+		ids[i] = entry.ID
+	}
+	return ids
+}
+
+func clusterDuplicates(pantry *entity.Pantry) []*duplicateCluster {
+	if pantry.Entries == nil {
+		return nil
+	}
+	var clusters []*duplicateCluster
+	byKey := map[string][]*duplicateCluster{}
+	for i := range *pantry.Entries {
+		entry := &(*pantry.Entries)[i]
+		key := entryIngredientID(entry) + "\x00" + stringValue(entry.LocationID)
+
+		merged := false
+		for _, cluster := range byKey[key] {
+			if cluster.absorb(entry) {
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			cluster := &duplicateCluster{original: entry, keep: cloneEntry(entry)}
+			byKey[key] = append(byKey[key], cluster)
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters
+}
+
+// absorb folds entry into the cluster if their quantities can be combined.
+// An entry without a quantity combines with anything.
+func (c *duplicateCluster) absorb(entry *entity.PantryEntry) bool {
+	switch {
+	case entry.Quantity == nil:
+	case c.keep.Quantity == nil:
+		c.keep.Quantity = entry.Quantity
+		c.keep.QuantityType = entry.QuantityType
+	default:
+		amount, err := units.ConvertAmount(*entry.Quantity, stringValue(entry.QuantityType), stringValue(c.keep.QuantityType), entry.Name)
+		if err != nil {
+			return false
+		}
+		total := *c.keep.Quantity + amount
+		c.keep.Quantity = &total
+	}
+
+	c.keep.Expiration = earliest(c.keep.Expiration, entry.Expiration)
+	for _, tag := range entry.Tags {
+		if !containsTag(c.keep.Tags, tag) {
+			c.keep.Tags = append(c.keep.Tags, tag)
+		}
+	}
+	c.merged = append(c.merged, entry)
+	return true
+}
+
+func sameLocation(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// earliest returns the earlier of two optional times. A missing time means the
+// entry does not expire, so it only wins when both are missing.
+func earliest(a, b *time.Time) *time.Time {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case b.Before(*a):
+		return b
+	}
+	return a
+}
@@ -2,30 +2,292 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/thisausername99/pantry_butler/internal/domain/category"
 	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/ingredient"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/domain/shelflife"
+	"github.com/thisausername99/pantry_butler/internal/domain/units"
+	"go.uber.org/zap"
 )
 
 func (u *Usecase) GetAllPantryEntries(ctx context.Context, pantryID string) ([]entity.PantryEntry, error) {
-	return u.RepoWrapper.PantryRepo.GetPantryEntries(ctx, pantryID)
+	_, entries, err := u.getPantryWithEntries(ctx, pantryID)
+	return entries, err
+}
+
+// getPantryWithEntries authorizes the caller as a viewer and returns the
+// pantry together with all of its entries, as loaded by authorize.
+func (u *Usecase) getPantryWithEntries(ctx context.Context, pantryID string) (*entity.Pantry, []entity.PantryEntry, error) {
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleViewer)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pantry, pantry.EntryList(), nil
+}
+
+// FindPantryEntries returns the entries of a pantry matching the filter.
+func (u *Usecase) FindPantryEntries(ctx context.Context, pantryID string, filter *entity.PantryEntryFilter) ([]entity.PantryEntry, error) {
+	if _, err := u.authorize(ctx, pantryID, entity.PantryRoleViewer); err != nil {
+		return nil, err
+	}
+	filter.Tags = category.NormalizeTags(filter.Tags)
+	return u.RepoWrapper.PantryRepo.FindPantryEntries(ctx, pantryID, filter)
 }
 
 func (u *Usecase) InsertPantryEntry(ctx context.Context, pantryID string, pantryEntryInput *entity.PantryEntryInput) error {
+	_, err := u.insertPantryEntry(ctx, pantryID, pantryEntryInput)
+	return err
+}
+
+// insertPantryEntry builds an entry from the input and stores it.
+func (u *Usecase) insertPantryEntry(ctx context.Context, pantryID string, pantryEntryInput *entity.PantryEntryInput) (*entity.PantryEntry, error) {
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := buildPantryEntry(pantry, pantryEntryInput, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.storePantryEntry(ctx, pantryID, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (u *Usecase) storePantryEntry(ctx context.Context, pantryID string, entry *entity.PantryEntry) error {
+	if err := u.RepoWrapper.PantryRepo.InsertPantryEntry(ctx, pantryID, entry); err != nil {
+		return err
+	}
+	return u.recordHistory(ctx, pantryID, entity.HistoryActionEntryInserted, &entry.ID, nil, entry)
+}
+
+// buildPantryEntry validates an input against the pantry and turns it into a
+// new entry, filling in a default expiration and category where none was
+// given.
+func buildPantryEntry(pantry *entity.Pantry, input *entity.PantryEntryInput, now time.Time) (*entity.PantryEntry, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.New("entry name is required")
+	}
+	if input.Quantity != nil && *input.Quantity < 0 {
+		return nil, errors.New("quantity cannot be negative")
+	}
+	if input.Category != nil && !input.Category.IsValid() {
+		return nil, fmt.Errorf("unknown category %q", *input.Category)
+	}
+	if input.LocationID != nil && pantry.Location(*input.LocationID) == nil {
+		return nil, fmt.Errorf("%w: %s", repository.ErrLocationNotFound, *input.LocationID)
+	}
+
 	entry := &entity.PantryEntry{
 		ID:           uuid.New().String(),
-		Name:         pantryEntryInput.Name,
-		Quantity:     pantryEntryInput.Quantity,
-		QuantityType: pantryEntryInput.QuantityType,
+		Name:         name,
+		IngredientID: ingredient.Resolve(name),
+		Quantity:     input.Quantity,
+		QuantityType: input.QuantityType,
+		Expiration:   input.Expiration,
+		LocationID:   input.LocationID,
+		Category:     input.Category,
+		Tags:         category.NormalizeTags(input.Tags),
+	}
+	if entry.Expiration == nil {
+		entry.Expiration = shelflife.DefaultExpiration(entry.Name, now)
+	}
+	if entry.Category == nil {
+		inferred := category.Infer(entry.Name)
+		entry.Category = &inferred
+	}
+	return entry, nil
+}
+
+func (u *Usecase) UpdatePantryEntry(ctx context.Context, pantryID string, entryID string, patch *entity.PantryEntryPatch) error {
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return err
+	}
+	if patch.Tags != nil {
+		patch.Tags = category.NormalizeTags(patch.Tags)
+	}
+	if patch.Name != nil {
+		id := ingredient.Resolve(*patch.Name)
+		patch.IngredientID = &id
+	}
+	if err := u.RepoWrapper.PantryRepo.UpdatePantryEntry(ctx, pantryID, entryID, patch); err != nil {
+		return err
+	}
+	return u.recordEntryChange(ctx, pantry, entryID, entity.HistoryActionEntryUpdated, func(entry *entity.PantryEntry) *entity.PantryEntry {
+		return applyPatch(entry, patch)
+	})
+}
+
+// TagPantryEntry adds tags to an entry, ignoring ones it already carries.
+func (u *Usecase) TagPantryEntry(ctx context.Context, pantryID string, entryID string, tags []string) error {
+	tags = category.NormalizeTags(tags)
+	if len(tags) == 0 {
+		return errors.New("at least one tag is required")
+	}
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return err
 	}
+	if err := u.RepoWrapper.PantryRepo.TagPantryEntry(ctx, pantryID, entryID, tags); err != nil {
+		return err
+	}
+	return u.recordEntryChange(ctx, pantry, entryID, entity.HistoryActionEntryUpdated, func(entry *entity.PantryEntry) *entity.PantryEntry {
+		for _, tag := range tags {
+			if !containsTag(entry.Tags, tag) {
+				entry.Tags = append(entry.Tags, tag)
+			}
+		}
+		return entry
+	})
+}
 
-	return u.RepoWrapper.PantryRepo.InsertPantryEntry(ctx, pantryID, entry)
+func (u *Usecase) UntagPantryEntry(ctx context.Context, pantryID string, entryID string, tags []string) error {
+	tags = category.NormalizeTags(tags)
+	if len(tags) == 0 {
+		return errors.New("at least one tag is required")
+	}
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return err
+	}
+	if err := u.RepoWrapper.PantryRepo.UntagPantryEntry(ctx, pantryID, entryID, tags); err != nil {
+		return err
+	}
+	return u.recordEntryChange(ctx, pantry, entryID, entity.HistoryActionEntryUpdated, func(entry *entity.PantryEntry) *entity.PantryEntry {
+		kept := []string{}
+		for _, tag := range entry.Tags {
+			if !containsTag(tags, tag) {
+				kept = append(kept, tag)
+			}
+		}
+		entry.Tags = kept
+		return entry
+	})
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ConsumePantryEntry records usage of an entry. The amount may be given in any
+// unit compatible with the entry's own; when unit is nil the entry's unit is
+// assumed.
+func (u *Usecase) ConsumePantryEntry(ctx context.Context, pantryID string, entryID string, amount float64, unit *string) (*entity.ConsumeResult, error) {
+	if amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := pantry.Entry(entryID)
+	if entry == nil {
+		return nil, fmt.Errorf("%w: %s", repository.ErrPantryEntryNotFound, entryID)
+	}
+	if entry.Quantity == nil {
+		return nil, fmt.Errorf("entry %s does not track a quantity", entryID)
+	}
+
+	entryUnit := ""
+	if entry.QuantityType != nil {
+		entryUnit = *entry.QuantityType
+	}
+	consumed := amount
+	if unit != nil && *unit != entryUnit {
+		consumed, err = units.ConvertAmount(amount, *unit, entryUnit, entry.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	depleted, err := u.RepoWrapper.PantryRepo.ConsumePantryEntry(ctx, pantryID, entryID, consumed)
+	if err != nil {
+		u.Logger.Error("error consuming pantry entry", zap.String("entryID", entryID), zap.Error(err))
+		return nil, err
+	}
+
+	var after *entity.PantryEntry
+	if !depleted {
+		after = cloneEntry(entry)
+		remaining := *entry.Quantity - consumed
+		after.Quantity = &remaining
+	}
+	if err := u.recordHistory(ctx, pantryID, entity.HistoryActionEntryConsumed, &entryID, entry, after); err != nil {
+		return nil, err
+	}
+
+	return &entity.ConsumeResult{
+		EntryID:      entryID,
+		Consumed:     consumed,
+		QuantityType: entry.QuantityType,
+		Depleted:     depleted,
+	}, nil
 }
 
 func (u *Usecase) DeletePantryEntry(ctx context.Context, pantryID string, entryID string) error {
-	return u.RepoWrapper.PantryRepo.DeletePantryEntry(ctx, pantryID, entryID)
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor)
+	if err != nil {
+		return err
+	}
+	if err := u.RepoWrapper.PantryRepo.DeletePantryEntry(ctx, pantryID, entryID); err != nil {
+		return err
+	}
+	return u.recordHistory(ctx, pantryID, entity.HistoryActionEntryDeleted, &entryID, pantry.Entry(entryID), nil)
 }
 
 func (u *Usecase) CreateNewPantry(ctx context.Context, pantry *entity.Pantry) error {
 	return u.RepoWrapper.PantryRepo.CreateNewPantry(ctx, pantry)
 }
+
+// GetExpiringEntries returns the entries of a pantry that expire within the
+// given number of days, including those that have already expired, ordered
+// from the soonest expiration to the latest. Entries without an expiration
+// are skipped.
+func (u *Usecase) GetExpiringEntries(ctx context.Context, pantryID string, withinDays int) ([]entity.ExpiringEntry, error) {
+	if withinDays < 0 {
+		return nil, errors.New("withinDays cannot be negative")
+	}
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	entries := pantry.EntryList()
+
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, withinDays)
+	expiring := []entity.ExpiringEntry{}
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Expiration == nil || entry.Expiration.After(cutoff) {
+			continue
+		}
+		expiring = append(expiring, entity.ExpiringEntry{
+			Entry:               entry,
+			DaysUntilExpiration: int(math.Floor(entry.Expiration.Sub(now).Hours() / 24)),
+			Expired:             !entry.Expiration.After(now),
+		})
+	}
+
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].Entry.Expiration.Before(*expiring[j].Entry.Expiration)
+	})
+	return expiring, nil
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/barcode"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+func (u *Usecase) GetProductByBarcode(ctx context.Context, code string) (*entity.Product, error) {
+	normalized, err := barcode.Normalize(code)
+	if err != nil {
+		return nil, err
+	}
+	return u.RepoWrapper.ProductRepo.GetProduct(ctx, normalized)
+}
+
+// InsertPantryEntryByBarcode looks a scanned code up in the product catalog
+// and adds one package of that product to the pantry.
+func (u *Usecase) InsertPantryEntryByBarcode(ctx context.Context, pantryID string, code string) (*entity.PantryEntry, error) {
+	product, err := u.GetProductByBarcode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &entity.PantryEntryInput{
+		Name:         product.Name,
+		Quantity:     product.PackageSize,
+		QuantityType: product.DefaultUnit,
+		Category:     product.Category,
+	}
+	if input.Quantity == nil {
+		one := 1.0
+		input.Quantity = &one
+	}
+	return u.insertPantryEntry(ctx, pantryID, input)
+}
+
+// ImportProductsCSV adds or updates catalog products from CSV. The first row
+// is a header naming the columns; code and name are required, while unit,
+// package_size, category and brand are optional. Rows that fail validation
+// are reported and skipped rather than aborting the import.
+func (u *Usecase) ImportProductsCSV(ctx context.Context, r io.Reader) (*entity.ProductImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("catalog CSV is empty")
+		}
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"code", "name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("catalog CSV is missing the %q column", required)
+		}
+	}
+
+	result := &entity.ProductImportResult{Errors: []entity.ImportRowError{}}
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, entity.ImportRowError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		product, err := parseProductRecord(record, columns)
+		if err == nil {
+			err = u.RepoWrapper.ProductRepo.UpsertProduct(ctx, product)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, entity.ImportRowError{Row: row, Message: err.Error()})
+			continue
+		}
+		result.Imported++
+	}
+
+	u.Logger.Info("imported product catalog", zap.Int("imported", result.Imported), zap.Int("failed", len(result.Errors)))
+	return result, nil
+}
+
+func parseProductRecord(record []string, columns map[string]int) (*entity.Product, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	code, err := barcode.Normalize(field("code"))
+	if err != nil {
+		return nil, err
+	}
+	product := &entity.Product{Code: code, Name: field("name")}
+	if product.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	if brand := field("brand"); brand != "" {
+		product.Brand = &brand
+	}
+	if unit := strings.ToLower(field("unit")); unit != "" {
+		product.DefaultUnit = &unit
+	}
+	if size := field("package_size"); size != "" {
+		value, err := strconv.ParseFloat(size, 64)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("invalid package_size %q", size)
+		}
+		product.PackageSize = &value
+	}
+	if name := field("category"); name != "" {
+		category := entity.Category(strings.ToUpper(name))
+		if !category.IsValid() {
+			return nil, fmt.Errorf("unknown category %q", name)
+		}
+		product.Category = &category
+	}
+	return product, nil
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	minRatingStars     = 1
+	maxRatingStars     = 5
+	maxRatingNoteRunes = 1000
+)
+
+// RateRecipe records the caller's rating of a recipe, replacing any earlier
+// one, and folds the change into the recipe's aggregates. Both writes share a
+// transaction so the aggregates never miss a stored rating.
+func (u *Usecase) RateRecipe(ctx context.Context, recipeID string, stars int, note *string) (*entity.RecipeRating, error) {
+	userID := callerID(ctx)
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+	if stars < minRatingStars || stars > maxRatingStars {
+		return nil, fmt.Errorf("stars must be between %d and %d", minRatingStars, maxRatingStars)
+	}
+	if note != nil {
+		trimmed := strings.TrimSpace(*note)
+		if utf8.RuneCountInString(trimmed) > maxRatingNoteRunes {
+			return nil, fmt.Errorf("note cannot exceed %d characters", maxRatingNoteRunes)
+		}
+		note = &trimmed
+		if trimmed == "" {
+			note = nil
+		}
+	}
+	if _, err := u.RepoWrapper.RecipeRepo.GetRecipe(ctx, recipeID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rating := &entity.RecipeRating{
+		RecipeID:  recipeID,
+		UserID:    userID,
+		Stars:     stars,
+		Note:      note,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err := u.RepoWrapper.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		previous, err := u.RepoWrapper.RatingRepo.UpsertRating(ctx, rating)
+		if err != nil {
+			return err
+		}
+
+		starsDelta, countDelta := stars, 1
+		if previous != nil {
+			rating.CreatedAt = previous.CreatedAt
+			starsDelta, countDelta = stars-previous.Stars, 0
+		}
+		if starsDelta == 0 && countDelta == 0 {
+			return nil
+		}
+		return u.RepoWrapper.RecipeRepo.AdjustRecipeRating(ctx, recipeID, starsDelta, countDelta)
+	})
+	if err != nil {
+		u.Logger.Error("error rating recipe",
+			zap.String("recipeId", recipeID),
+			zap.String("userId", userID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return rating, nil
+}
+
+// MyRating returns the caller's rating of a recipe, or nil if they have not
+// rated it.
+func (u *Usecase) MyRating(ctx context.Context, recipeID string) (*entity.RecipeRating, error) {
+	userID := callerID(ctx)
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+	rating, err := u.RepoWrapper.RatingRepo.GetRating(ctx, recipeID, userID)
+	if errors.Is(err, repository.ErrRatingNotFound) {
+		return nil, nil
+	}
+	return rating, err
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/recipeimport"
+	"go.uber.org/zap"
+)
+
+// maxRecipeImport bounds how many recipes one import may create.
+const maxRecipeImport = 500
+
+// RecipeImportFormatFromFilename picks the recipe import format from a
+// file's extension.
+func RecipeImportFormatFromFilename(name string) (entity.RecipeImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".html", ".htm", ".json", ".jsonld":
+		return entity.RecipeImportFormatJSONLd, nil
+	case ".md", ".markdown":
+		return entity.RecipeImportFormatMarkdown, nil
+	case ".mmf", ".mm":
+		return entity.RecipeImportFormatMealmaster, nil
+	}
+	return "", fmt.Errorf("cannot tell the recipe format of %q; use a .html, .json, .md or .mmf file", name)
+}
+
+// ImportRecipes creates the recipes in a JSON-LD, Markdown or Meal-Master
+// file, authored by the caller. Each recipe is validated like one created
+// through the API and stored on its own, so a bad recipe is reported without
+// holding back the others. A dry run validates and reports without writing.
+func (u *Usecase) ImportRecipes(ctx context.Context, r io.Reader, format entity.RecipeImportFormat, dryRun bool) (*entity.RecipeImportResult, error) {
+	userID := callerID(ctx)
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+	parsed, err := recipeimport.Parse(r, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed) > maxRecipeImport {
+		return nil, fmt.Errorf("an import can contain at most %d recipes, got %d", maxRecipeImport, len(parsed))
+	}
+
+	result := &entity.RecipeImportResult{
+		DryRun:  dryRun,
+		Recipes: []*entity.Recipe{},
+		Errors:  []*entity.RecipeImportError{},
+	}
+	for _, item := range parsed {
+		recipe, err := importedRecipe(item)
+		if err == nil && !dryRun {
+			recipe.ID = uuid.New().String()
+			recipe.CreatedBy = userID
+			if err = u.RepoWrapper.RecipeRepo.CreateRecipe(ctx, recipe); err != nil {
+				u.Logger.Error("error importing recipe", zap.String("name", recipe.Name), zap.Error(err))
+			}
+		}
+		if err != nil {
+			importErr := &entity.RecipeImportError{Index: item.Index, Message: err.Error()}
+			if name := item.Name; name != "" {
+				importErr.Name = &name
+			}
+			result.Errors = append(result.Errors, importErr)
+			continue
+		}
+		result.Recipes = append(result.Recipes, recipe)
+	}
+	if !dryRun {
+		result.Imported = len(result.Recipes)
+	}
+	return result, nil
+}
+
+// importedRecipe runs a parsed recipe through buildRecipe, so imports obey
+// the same rules and ingredient resolution as recipes created by hand.
+func importedRecipe(item recipeimport.Result) (*entity.Recipe, error) {
+	if item.Err != nil {
+		return nil, item.Err
+	}
+	parsed := item.Recipe
+	input := &entity.RecipeInput{
+		Name:        parsed.Name,
+		Description: &parsed.Description,
+		Cuisine:     parsed.Cuisine,
+		Difficulty:  parsed.Difficulty,
+		SourceURL:   parsed.SourceUrl,
+		Servings:    parsed.Servings,
+		PrepTime:    parsed.PrepTime,
+		CookTime:    parsed.CookTime,
+	}
+	for _, ingredient := range parsed.Ingredients {
+		input.Ingredients = append(input.Ingredients, &entity.RecipeIngredientInput{
+			Name:        ingredient.Name,
+			Quantity:    ingredient.Quantity,
+			Unit:        ingredient.Unit,
+			Optional:    ingredient.Optional,
+			Preparation: ingredient.Preparation,
+		})
+	}
+	for _, step := range parsed.Steps {
+		input.Steps = append(input.Steps, &entity.RecipeStepInput{
+			Text:            step.Text,
+			DurationMinutes: step.DurationMinutes,
+			Ingredients:     step.IngredientIDs,
+			Passive:         step.Passive,
+		})
+	}
+	return buildRecipe(input)
+}
@@ -2,8 +2,15 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/ingredient"
 )
 
 func (u *Usecase) GetAllRecipes(ctx context.Context) ([]entity.Recipe, error) {
@@ -14,34 +21,331 @@ func (u *Usecase) GetRecipesByCuisine(ctx context.Context, cuisine string) ([]en
 	return u.RepoWrapper.RecipeRepo.GetRecipesByCuisine(ctx, cuisine)
 }
 
-func (u *Usecase) GenerateRecipesFromPantry(ctx context.Context, pantryID string) ([]entity.Recipe, error) {
-	recipes, err := u.RepoWrapper.RecipeRepo.GetRecipes(ctx)
+// maxRecipeDifficulty is the top of the recipe difficulty scale, which
+// starts at 1.
+const maxRecipeDifficulty = 5
+
+// CreateRecipe stores a new recipe authored by the caller.
+func (u *Usecase) CreateRecipe(ctx context.Context, input *entity.RecipeInput) (*entity.Recipe, error) {
+	userID := callerID(ctx)
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+	recipe, err := buildRecipe(input)
+	if err != nil {
+		return nil, err
+	}
+	recipe.ID = uuid.New().String()
+	recipe.CreatedBy = userID
+	if err := u.RepoWrapper.RecipeRepo.CreateRecipe(ctx, recipe); err != nil {
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// UpdateRecipe replaces the content of a recipe.
+func (u *Usecase) UpdateRecipe(ctx context.Context, recipeID string, input *entity.RecipeInput) (*entity.Recipe, error) {
+	existing, err := u.authorizeRecipe(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	recipe, err := buildRecipe(input)
+	if err != nil {
+		return nil, err
+	}
+	recipe.ID = existing.ID
+	recipe.CreatedBy = existing.CreatedBy
+	recipe.RatingAverage = existing.RatingAverage
+	recipe.RatingCount = existing.RatingCount
+	recipe.RatingTotal = existing.RatingTotal
+	if err := u.RepoWrapper.RecipeRepo.UpdateRecipe(ctx, recipe); err != nil {
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// DeleteRecipe removes a recipe along with its ratings.
+func (u *Usecase) DeleteRecipe(ctx context.Context, recipeID string) error {
+	if _, err := u.authorizeRecipe(ctx, recipeID); err != nil {
+		return err
+	}
+	return u.RepoWrapper.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := u.RepoWrapper.RecipeRepo.DeleteRecipe(ctx, recipeID); err != nil {
+			return err
+		}
+		return u.RepoWrapper.RatingRepo.DeleteRatings(ctx, recipeID)
+	})
+}
+
+// authorizeRecipe loads a recipe for an authenticated caller to change.
+// Recipes are shared, so any signed-in user may edit one, including the
+// seeded recipes that have no author.
+func (u *Usecase) authorizeRecipe(ctx context.Context, recipeID string) (*entity.Recipe, error) {
+	if callerID(ctx) == "" {
+		return nil, ErrUnauthenticated
+	}
+	return u.RepoWrapper.RecipeRepo.GetRecipe(ctx, recipeID)
+}
+
+// buildRecipe validates a recipe input and resolves its ingredients against
+// the ingredient catalog.
+func buildRecipe(input *entity.RecipeInput) (*entity.Recipe, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.New("recipe name is required")
+	}
+	if len(input.Ingredients) == 0 {
+		return nil, errors.New("a recipe needs at least one ingredient")
+	}
+	if input.Difficulty != nil && (*input.Difficulty < 1 || *input.Difficulty > maxRecipeDifficulty) {
+		return nil, fmt.Errorf("difficulty must be between 1 and %d", maxRecipeDifficulty)
+	}
+	if input.Servings != nil && *input.Servings <= 0 {
+		return nil, errors.New("servings must be positive")
+	}
+	if (input.PrepTime != nil && *input.PrepTime < 0) || (input.CookTime != nil && *input.CookTime < 0) {
+		return nil, errors.New("preparation and cooking times cannot be negative")
+	}
+
+	recipe := &entity.Recipe{
+		Name:        name,
+		Description: strings.TrimSpace(stringValue(input.Description)),
+		Cuisine:     input.Cuisine,
+		Difficulty:  input.Difficulty,
+		SourceUrl:   input.SourceURL,
+		Servings:    input.Servings,
+		PrepTime:    input.PrepTime,
+		CookTime:    input.CookTime,
+		Ingredients: make([]entity.RecipeIngredient, 0, len(input.Ingredients)),
+	}
+	for i, item := range input.Ingredients {
+		itemName := strings.TrimSpace(item.Name)
+		if itemName == "" {
+			return nil, fmt.Errorf("ingredient %d: name is required", i+1)
+		}
+		if item.Quantity != nil && *item.Quantity <= 0 {
+			return nil, fmt.Errorf("ingredient %q: quantity must be positive", itemName)
+		}
+		if item.Unit != nil && item.Quantity == nil {
+			return nil, fmt.Errorf("ingredient %q: unit given without a quantity", itemName)
+		}
+		recipe.Ingredients = append(recipe.Ingredients, entity.RecipeIngredient{
+			Name:         itemName,
+			IngredientID: ingredient.Resolve(itemName),
+			Quantity:     item.Quantity,
+			Unit:         item.Unit,
+			Optional:     item.Optional,
+			Preparation:  item.Preparation,
+		})
+	}
+
+	steps, err := buildRecipeSteps(input.Steps, recipe.Ingredients)
 	if err != nil {
 		return nil, err
 	}
+	recipe.Steps = steps
+	return recipe, nil
+}
+
+// buildRecipeSteps validates the method of a recipe and resolves each step's
+// ingredient references, by name or ID, to the recipe's own ingredients.
+func buildRecipeSteps(inputs []*entity.RecipeStepInput, ingredients []entity.RecipeIngredient) ([]entity.RecipeStep, error) {
+	known := make(map[string]bool, len(ingredients))
+	for _, item := range ingredients {
+		known[item.IngredientID] = true
+	}
+
+	steps := make([]entity.RecipeStep, 0, len(inputs))
+	for i, input := range inputs {
+		text := strings.TrimSpace(input.Text)
+		if text == "" {
+			return nil, fmt.Errorf("step %d: text is required", i+1)
+		}
+		if input.DurationMinutes != nil && *input.DurationMinutes < 0 {
+			return nil, fmt.Errorf("step %d: duration cannot be negative", i+1)
+		}
+		step := entity.RecipeStep{Text: text, DurationMinutes: input.DurationMinutes, Passive: input.Passive}
+		for _, ref := range input.Ingredients {
+			id := ingredient.Resolve(ref)
+			if !known[id] {
+				return nil, fmt.Errorf("step %d: %q is not an ingredient of the recipe", i+1, ref)
+			}
+			if !slices.Contains(step.IngredientIDs, id) {
+				step.IngredientIDs = append(step.IngredientIDs, id)
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
 
-	pantryEntries, err := u.RepoWrapper.PantryRepo.GetPantryEntries(ctx, pantryID)
+// GenerateRecipesFromPantry ranks recipes by how much of them the pantry
+// covers. Coverage is the share of a recipe's ingredients on hand; recipes
+// sharing no ingredient with the pantry are left out. maxMissing caps the
+// number of missing ingredients and minCoverage, between 0 and 1, sets the
+// lowest acceptable coverage. Suggestions are ordered by coverage, then by
+// fewest missing ingredients, then by name.
+// With useSubstitutes, a missing ingredient whose substitution rule is wholly
+// on hand counts as present and the substitution is reported.
+func (u *Usecase) GenerateRecipesFromPantry(ctx context.Context, pantryID string, maxMissing *int, minCoverage *float64, useSubstitutes bool) ([]*entity.RecipeSuggestion, error) {
+	if maxMissing != nil && *maxMissing < 0 {
+		return nil, errors.New("maxMissing cannot be negative")
+	}
+	if minCoverage != nil && (*minCoverage < 0 || *minCoverage > 1) {
+		return nil, errors.New("minCoverage must be between 0 and 1")
+	}
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	recipes, err := u.RepoWrapper.RecipeRepo.GetRecipes(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	pantrySet := make(map[string]struct{})
-	for _, entry := range pantryEntries {
-		pantrySet[entry.Name] = struct{}{}
+	available := pantryIngredients(pantry.EntryList())
+	var substitutes map[string][]entity.Substitution
+	if useSubstitutes {
+		substitutes, err = u.substitutesFor(ctx, recipes, available)
+		if err != nil {
+			return nil, err
+		}
+	}
+	suggestions := []*entity.RecipeSuggestion{}
+	for i := range recipes {
+		suggestion := suggestRecipe(&recipes[i], available, substitutes)
+		if suggestion == nil {
+			continue
+		}
+		if maxMissing != nil && len(suggestion.MissingIngredients) > *maxMissing {
+			continue
+		}
+		if minCoverage != nil && suggestion.Coverage < *minCoverage {
+			continue
+		}
+		suggestions = append(suggestions, suggestion)
 	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Coverage != b.Coverage {
+			return a.Coverage > b.Coverage
+		}
+		if len(a.MissingIngredients) != len(b.MissingIngredients) {
+			return len(a.MissingIngredients) < len(b.MissingIngredients)
+		}
+		return a.Recipe.Name < b.Recipe.Name
+	})
+	return suggestions, nil
+}
 
-	var matches []entity.Recipe
-	for _, recipe := range recipes {
-		allIngredientsPresent := true
-		for ingredient := range recipe.Ingredients {
-			if _, ok := pantrySet[ingredient]; !ok {
-				allIngredientsPresent = false
-				break
+// suggestRecipe scores a recipe against the ingredients on hand, or returns
+// nil when the pantry holds none of them. Optional ingredients are used when
+// present but never count as missing or against coverage. Required
+// ingredients that are missing are replaced using substitutes, keyed by
+// ingredient ID, when a rule's replacement is on hand.
+func suggestRecipe(recipe *entity.Recipe, available map[string]*entity.PantryEntry, substitutes map[string][]entity.Substitution) *entity.RecipeSuggestion {
+	suggestion := &entity.RecipeSuggestion{
+		Recipe:             recipe,
+		MissingIngredients: []string{},
+		UsedEntries:        []*entity.PantryEntry{},
+		Substitutions:      []*entity.AppliedSubstitution{},
+	}
+	used := map[string]bool{}
+	use := func(entry *entity.PantryEntry) {
+		if !used[entry.ID] {
+			used[entry.ID] = true
+			suggestion.UsedEntries = append(suggestion.UsedEntries, entry)
+		}
+	}
+	required, matched := 0, 0
+	for i := range recipe.Ingredients {
+		item := &recipe.Ingredients[i]
+		if !item.Optional {
+			required++
+		}
+		id := recipeIngredientID(item)
+		entry, ok := available[id]
+		if !ok {
+			if item.Optional {
+				continue
+			}
+			applied, entries := substitute(item, substitutes[id], available)
+			if applied == nil {
+				suggestion.MissingIngredients = append(suggestion.MissingIngredients, item.Name)
+				continue
 			}
+			matched++
+			suggestion.Substitutions = append(suggestion.Substitutions, applied)
+			for _, entry := range entries {
+				use(entry)
+			}
+			continue
+		}
+		if !item.Optional {
+			matched++
 		}
-		if allIngredientsPresent {
-			matches = append(matches, recipe)
+		use(entry)
+	}
+	if matched == 0 {
+		return nil
+	}
+	sort.Strings(suggestion.MissingIngredients)
+	suggestion.Coverage = float64(matched) / float64(required)
+	return suggestion
+}
+
+// pantryIngredients indexes the entries on hand by canonical ingredient ID.
+// Where several entries hold the same ingredient the one expiring first is
+// kept, since that is the one to use up.
+func pantryIngredients(entries []entity.PantryEntry) map[string]*entity.PantryEntry {
+	available := make(map[string]*entity.PantryEntry, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+		id := entryIngredientID(entry)
+		if current, ok := available[id]; !ok || expiresBefore(entry, current) {
+			available[id] = entry
+		}
+	}
+	return available
+}
+
+// missingIngredients lists, in alphabetical order, the required ingredients
+// of a recipe whose canonical IDs are not among the available ones.
+func missingIngredients(recipe *entity.Recipe, available map[string]*entity.PantryEntry) []string {
+	missing := []string{}
+	for i := range recipe.Ingredients {
+		item := &recipe.Ingredients[i]
+		if _, ok := available[recipeIngredientID(item)]; !ok && !item.Optional {
+			missing = append(missing, item.Name)
 		}
 	}
-	return matches, nil
+	sort.Strings(missing)
+	return missing
+}
+
+// expiresBefore reports whether a expires before b. Entries without an
+// expiration sort last.
+func expiresBefore(a, b *entity.PantryEntry) bool {
+	if a.Expiration == nil {
+		return false
+	}
+	return b.Expiration == nil || a.Expiration.Before(*b.Expiration)
+}
+
+// entryIngredientID returns the canonical ingredient of an entry. Entries
+// stored before the catalog existed have none and are resolved by name.
+func entryIngredientID(entry *entity.PantryEntry) string {
+	if entry.IngredientID != "" {
+		return entry.IngredientID
+	}
+	return ingredient.Resolve(entry.Name)
+}
+
+// recipeIngredientID returns the canonical ID of a recipe ingredient,
+// resolving it by name when the recipe was stored without one.
+func recipeIngredientID(item *entity.RecipeIngredient) string {
+	if item.IngredientID != "" {
+		return item.IngredientID
+	}
+	return ingredient.Resolve(item.Name)
 }
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/units"
+)
+
+// maxScaledServings keeps scaling requests within what a kitchen could cook.
+const maxScaledServings = 1000
+
+// ScaleRecipe returns a recipe with its ingredient quantities adjusted to
+// make the given number of servings. Quantities are rounded to amounts a cook
+// can measure. The stored recipe is not changed.
+func (u *Usecase) ScaleRecipe(ctx context.Context, recipeID string, servings int) (*entity.Recipe, error) {
+	recipe, factor, err := u.recipeForServings(ctx, recipeID, servings)
+	if err != nil {
+		return nil, err
+	}
+	return withIngredients(recipe, servings, func(item entity.RecipeIngredient) entity.RecipeIngredient {
+		return scaleIngredient(item, factor)
+	}), nil
+}
+
+// recipeForServings loads a recipe and works out the factor that takes it
+// to the given number of servings.
+func (u *Usecase) recipeForServings(ctx context.Context, recipeID string, servings int) (*entity.Recipe, float64, error) {
+	if servings <= 0 || servings > maxScaledServings {
+		return nil, 0, fmt.Errorf("servings must be between 1 and %d", maxScaledServings)
+	}
+	recipe, err := u.RepoWrapper.RecipeRepo.GetRecipe(ctx, recipeID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if recipe.Servings == nil || *recipe.Servings <= 0 {
+		return nil, 0, errors.New("recipe does not say how many servings it makes")
+	}
+	return recipe, float64(servings) / float64(*recipe.Servings), nil
+}
+
+// withIngredients copies a recipe for the given servings, passing each of its
+// ingredients through change.
+func withIngredients(recipe *entity.Recipe, servings int, change func(entity.RecipeIngredient) entity.RecipeIngredient) *entity.Recipe {
+	scaled := *recipe
+	scaled.Servings = &servings
+	scaled.Ingredients = make([]entity.RecipeIngredient, len(recipe.Ingredients))
+	for i, item := range recipe.Ingredients {
+		scaled.Ingredients[i] = change(item)
+	}
+	return &scaled
+}
+
+// scaleIngredient scales one ingredient. Ingredients without a quantity,
+// such as salt to taste, are left alone.
+func scaleIngredient(item entity.RecipeIngredient, factor float64) entity.RecipeIngredient {
+	if item.Quantity == nil {
+		return item
+	}
+	quantity, unit := scaleAmount(*item.Quantity, item.Unit, factor)
+	item.Quantity, item.Unit = &quantity, unit
+	return item
+}
+
+// scaleAmount scales an amount in a free-form unit, moving it to a friendlier
+// unit where the units package knows one.
+func scaleAmount(amount float64, unit *string, factor float64) (float64, *string) {
+	q := units.Scale(units.Quantity{Amount: amount, Unit: units.Resolve(stringValue(unit))}, factor)
+	switch {
+	case q.Unit == units.Piece:
+		return q.Amount, nil
+	case q.Unit.Dimension == units.Other:
+		// Keep the recipe's own spelling of units the package does not know.
+		return q.Amount, unit
+	default:
+		symbol := q.Unit.Symbol
+		return q.Amount, &symbol
+	}
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/domain/search"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchRecipes finds recipes by words in their name, ingredients and
+// description, most relevant first. after is the endCursor of the previous
+// page. When the store cannot search, recipes are ranked in memory instead.
+func (u *Usecase) SearchRecipes(ctx context.Context, query string, limit *int, after *string) (*entity.RecipeSearchPage, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query is required")
+	}
+	pageSize := defaultSearchLimit
+	if limit != nil {
+		pageSize = *limit
+	}
+	if pageSize <= 0 || pageSize > maxSearchLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxSearchLimit)
+	}
+	offset := 0
+	if after != nil {
+		var err error
+		if offset, err = decodeSearchCursor(*after); err != nil {
+			return nil, err
+		}
+	}
+
+	page := &entity.RecipeSearchPage{Hits: []*entity.RecipeSearchHit{}}
+	if len(search.Terms(query)) == 0 {
+		return page, nil
+	}
+
+	// One extra hit tells whether another page follows.
+	hits, err := u.RepoWrapper.RecipeRepo.SearchRecipes(ctx, query, offset, pageSize+1)
+	if errors.Is(err, repository.ErrSearchUnsupported) {
+		hits, err = u.searchRecipesInMemory(ctx, query, offset, pageSize+1)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hits) > pageSize {
+		hits = hits[:pageSize]
+		page.HasNextPage = true
+	}
+	for i := range hits {
+		page.Hits = append(page.Hits, &hits[i])
+	}
+	if len(hits) > 0 {
+		cursor := encodeSearchCursor(offset + len(hits))
+		page.EndCursor = &cursor
+	}
+	return page, nil
+}
+
+func (u *Usecase) searchRecipesInMemory(ctx context.Context, query string, offset int, limit int) ([]entity.RecipeSearchHit, error) {
+	recipes, err := u.RepoWrapper.RecipeRepo.GetRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hits := search.Recipes(recipes, query)
+	if offset >= len(hits) {
+		return nil, nil
+	}
+	hits = hits[offset:]
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// Search cursors are opaque to clients; they carry the offset of the next
+// hit.
+const searchCursorPrefix = "offset:"
+
+func encodeSearchCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(searchCursorPrefix + strconv.Itoa(offset)))
+}
+
+func decodeSearchCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		if value, ok := strings.CutPrefix(string(raw), searchCursorPrefix); ok {
+			if offset, err := strconv.Atoi(value); err == nil && offset >= 0 {
+				return offset, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid cursor %q", cursor)
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+func (u *Usecase) GetShoppingLists(ctx context.Context, pantryID string) ([]entity.ShoppingList, error) {
+	if _, err := u.authorize(ctx, pantryID, entity.PantryRoleViewer); err != nil {
+		return nil, err
+	}
+	return u.RepoWrapper.ShoppingListRepo.GetShoppingLists(ctx, pantryID)
+}
+
+func (u *Usecase) GetShoppingList(ctx context.Context, listID string) (*entity.ShoppingList, error) {
+	return u.authorizeShoppingList(ctx, listID, entity.PantryRoleViewer)
+}
+
+func (u *Usecase) CreateShoppingList(ctx context.Context, pantryID string, name string) (*entity.ShoppingList, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("shopping list name is required")
+	}
+	if _, err := u.authorize(ctx, pantryID, entity.PantryRoleEditor); err != nil {
+		return nil, err
+	}
+
+	list := &entity.ShoppingList{
+		ID:        uuid.New().String(),
+		PantryID:  pantryID,
+		Name:      name,
+		Items:     []entity.ShoppingListItem{},
+		CreatedAt: time.Now(),
+	}
+	if err := u.RepoWrapper.ShoppingListRepo.CreateShoppingList(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (u *Usecase) AddShoppingListItem(ctx context.Context, listID string, input *entity.ShoppingListItemInput) (*entity.ShoppingListItem, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.New("item name is required")
+	}
+	if input.Quantity != nil && *input.Quantity <= 0 {
+		return nil, errors.New("quantity must be positive")
+	}
+	if _, err := u.authorizeShoppingList(ctx, listID, entity.PantryRoleEditor); err != nil {
+		return nil, err
+	}
+
+	item := &entity.ShoppingListItem{
+		ID:       uuid.New().String(),
+		Name:     name,
+		Quantity: input.Quantity,
+		Unit:     input.Unit,
+		AddedBy:  callerID(ctx),
+	}
+	if err := u.RepoWrapper.ShoppingListRepo.AddShoppingListItem(ctx, listID, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// CheckShoppingListItem checks an item off, or back on when checked is false.
+// When addToPantry is given, checking the item off also stocks it in the
+// list's pantry. Checking an item that is already checked is a no-op and
+// never adds it to the pantry a second time. The list is read and both writes
+// made in one transaction, so an item is never left checked off without
+// reaching the pantry.
+func (u *Usecase) CheckShoppingListItem(ctx context.Context, listID string, itemID string, checked bool, addToPantry *entity.AddToPantryInput) (*entity.CheckShoppingItemResult, error) {
+	if !checked && addToPantry != nil {
+		return nil, errors.New("only checked items can be added to the pantry")
+	}
+
+	var result *entity.CheckShoppingItemResult
+	err := u.RepoWrapper.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		list, err := u.authorizeShoppingList(ctx, listID, entity.PantryRoleEditor)
+		if err != nil {
+			return err
+		}
+		item := list.Item(itemID)
+		if item == nil {
+			return fmt.Errorf("%w: %s", repository.ErrShoppingItemNotFound, itemID)
+		}
+
+		now := time.Now()
+		changed, err := u.RepoWrapper.ShoppingListRepo.SetShoppingListItemChecked(ctx, listID, itemID, checked, now)
+		if err != nil {
+			return err
+		}
+		item.Checked = checked
+		item.CheckedAt = nil
+		if checked {
+			item.CheckedAt = &now
+		}
+		result = &entity.CheckShoppingItemResult{Item: item}
+		if !changed || addToPantry == nil {
+			return nil
+		}
+
+		result.Entry, err = u.insertPantryEntry(ctx, list.PantryID, &entity.PantryEntryInput{
+			Name:         item.Name,
+			Quantity:     item.Quantity,
+			QuantityType: item.Unit,
+			Expiration:   addToPantry.Expiration,
+			LocationID:   addToPantry.LocationID,
+		})
+		return err
+	})
+	if err != nil {
+		u.Logger.Error("error checking shopping list item", zap.String("itemID", itemID), zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// ClearShoppingList removes the checked items from a list, or all of its items
+// when checkedOnly is false.
+func (u *Usecase) ClearShoppingList(ctx context.Context, listID string, checkedOnly bool) error {
+	if _, err := u.authorizeShoppingList(ctx, listID, entity.PantryRoleEditor); err != nil {
+		return err
+	}
+	return u.RepoWrapper.ShoppingListRepo.ClearShoppingListItems(ctx, listID, checkedOnly)
+}
+
+// authorizeShoppingList loads a list and checks the caller's role in the
+// pantry it belongs to.
+func (u *Usecase) authorizeShoppingList(ctx context.Context, listID string, required entity.PantryRole) (*entity.ShoppingList, error) {
+	if callerID(ctx) == "" {
+		return nil, ErrUnauthenticated
+	}
+	list, err := u.RepoWrapper.ShoppingListRepo.GetShoppingList(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.authorize(ctx, list.PantryID, required); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/ingredient"
+	"github.com/thisausername99/pantry_butler/internal/domain/units"
+)
+
+// Substitutions lists the rules for replacing an ingredient, given by name
+// or ID, simplest replacement first.
+func (u *Usecase) Substitutions(ctx context.Context, name string) ([]entity.Substitution, error) {
+	rules, err := u.RepoWrapper.SubstitutionRepo.GetSubstitutions(ctx, []string{ingredient.Resolve(name)})
+	if err != nil {
+		return nil, err
+	}
+	sortSubstitutions(rules)
+	return rules, nil
+}
+
+// substitutesFor loads the rules for every required ingredient of the
+// recipes that the pantry lacks, indexed by the ingredient they replace.
+func (u *Usecase) substitutesFor(ctx context.Context, recipes []entity.Recipe, available map[string]*entity.PantryEntry) (map[string][]entity.Substitution, error) {
+	seen := map[string]bool{}
+	missing := []string{}
+	for i := range recipes {
+		for j := range recipes[i].Ingredients {
+			item := &recipes[i].Ingredients[j]
+			id := recipeIngredientID(item)
+			if _, ok := available[id]; ok || item.Optional || seen[id] {
+				continue
+			}
+			seen[id] = true
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	sort.Strings(missing)
+
+	rules, err := u.RepoWrapper.SubstitutionRepo.GetSubstitutions(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	sortSubstitutions(rules)
+	byIngredient := map[string][]entity.Substitution{}
+	for _, rule := range rules {
+		byIngredient[rule.IngredientID] = append(byIngredient[rule.IngredientID], rule)
+	}
+	return byIngredient, nil
+}
+
+// substitute applies the first rule whose replacement is wholly on hand and
+// returns it with the entries it draws on, or nil when none applies.
+func substitute(item *entity.RecipeIngredient, rules []entity.Substitution, available map[string]*entity.PantryEntry) (*entity.AppliedSubstitution, []*entity.PantryEntry) {
+	for i := range rules {
+		rule := &rules[i]
+		entries := make([]*entity.PantryEntry, 0, len(rule.Replacement))
+		for _, part := range rule.Replacement {
+			entry, ok := available[part.IngredientID]
+			if !ok {
+				break
+			}
+			entries = append(entries, entry)
+		}
+		if len(entries) < len(rule.Replacement) {
+			continue
+		}
+		return &entity.AppliedSubstitution{
+			Ingredient:  item.Name,
+			Rule:        rule,
+			Replacement: scaleReplacement(item, rule),
+		}, entries
+	}
+	return nil, nil
+}
+
+// scaleReplacement works out the replacement for the amount a recipe calls
+// for. The rule's own amounts are returned when the recipe gives no quantity
+// or its unit does not convert into the rule's.
+func scaleReplacement(item *entity.RecipeIngredient, rule *entity.Substitution) []*entity.SubstitutionPart {
+	factor := 1.0
+	if item.Quantity != nil && rule.Quantity > 0 {
+		amount, err := units.ConvertAmount(*item.Quantity, stringValue(item.Unit), stringValue(rule.Unit), item.Name)
+		if err == nil {
+			factor = amount / rule.Quantity
+		}
+	}
+
+	parts := make([]*entity.SubstitutionPart, len(rule.Replacement))
+	for i := range rule.Replacement {
+		part := rule.Replacement[i]
+		if factor != 1 {
+			part.Quantity, part.Unit = scaleAmount(part.Quantity, part.Unit, factor)
+		}
+		parts[i] = &part
+	}
+	return parts
+}
+
+// sortSubstitutions puts rules with fewer replacement parts first, since
+// they are the easiest to satisfy, breaking ties by ID.
+func sortSubstitutions(rules []entity.Substitution) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if len(rules[i].Replacement) != len(rules[j].Replacement) {
+			return len(rules[i].Replacement) < len(rules[j].Replacement)
+		}
+		return rules[i].ID < rules[j].ID
+	})
+}
@@ -0,0 +1,203 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/usecase"
+)
+
+// expectTransaction runs the unit of work handed to the transactor.
+func expectTransaction(ctx context.Context) {
+	mockTransactor.EXPECT().
+		WithTransaction(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Times(1)
+}
+
+func cookingRecipe() *entity.Recipe {
+	return &entity.Recipe{
+		ID:       "recipe-1",
+		Name:     "Pancakes",
+		Servings: intPtr(2),
+		Ingredients: []entity.RecipeIngredient{
+			{Name: "milk", IngredientID: "milk", Quantity: float64Ptr(500), Unit: stringPtr("ml")},
+			{Name: "egg", IngredientID: "egg", Quantity: float64Ptr(3)},
+			{Name: "maple syrup", IngredientID: "maple_syrup", Quantity: float64Ptr(2), Unit: stringPtr("tbsp"), Optional: true},
+			{Name: "salt", IngredientID: "salt"},
+		},
+	}
+}
+
+func TestCookRecipe_DrawsOldestEntriesFirst(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	soon := time.Now().AddDate(0, 0, 2)
+	later := time.Now().AddDate(0, 0, 9)
+	expectMemberWithEntries(ctx, entity.PantryRoleEditor, []entity.PantryEntry{
+		{ID: "milk-new", Name: "Milk", IngredientID: "milk", Quantity: float64Ptr(1), QuantityType: stringPtr("l"), Expiration: &later},
+		{ID: "milk-old", Name: "Milk", IngredientID: "milk", Quantity: float64Ptr(0.2), QuantityType: stringPtr("l"), Expiration: &soon},
+		{ID: "eggs", Name: "Eggs", IngredientID: "egg", Quantity: float64Ptr(6)},
+	})
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(cookingRecipe(), nil).Times(1)
+	expectTransaction(ctx)
+	gomock.InOrder(
+		mockPantryRepo.EXPECT().ConsumePantryEntry(ctx, testPantryID, "milk-old", 0.2).Return(true, nil),
+		mockPantryRepo.EXPECT().ConsumePantryEntry(ctx, testPantryID, "milk-new", gomock.Any()).Return(false, nil),
+		mockPantryRepo.EXPECT().ConsumePantryEntry(ctx, testPantryID, "eggs", 3.0).Return(false, nil),
+	)
+	expectHistory(ctx, entity.HistoryActionEntryConsumed)
+	expectHistory(ctx, entity.HistoryActionEntryConsumed)
+	expectHistory(ctx, entity.HistoryActionEntryConsumed)
+
+	report, err := usecaseInstance.CookRecipe(ctx, "recipe-1", testPantryID, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, report.Complete)
+	if assert.Len(t, report.Ingredients, 4) {
+		milk := report.Ingredients[0]
+		if assert.Len(t, milk.Used, 2) {
+			assert.Equal(t, "milk-old", milk.Used[0].EntryID)
+			assert.True(t, milk.Used[0].Depleted)
+			assert.InDelta(t, 0.3, milk.Used[1].Amount, 1e-9)
+		}
+		assert.Zero(t, milk.Short)
+		assert.Empty(t, report.Ingredients[2].Used)
+		assert.Zero(t, report.Ingredients[2].Short)
+		assert.Nil(t, report.Ingredients[3].Required)
+	}
+}
+
+func TestCookRecipe_ReportsShortfall(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMemberWithEntries(ctx, entity.PantryRoleEditor, []entity.PantryEntry{
+		{ID: "eggs", Name: "Eggs", IngredientID: "egg", Quantity: float64Ptr(2)},
+	})
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(cookingRecipe(), nil).Times(1)
+	expectTransaction(ctx)
+	mockPantryRepo.EXPECT().ConsumePantryEntry(ctx, testPantryID, "eggs", 2.0).Return(true, nil).Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryConsumed)
+
+	report, err := usecaseInstance.CookRecipe(ctx, "recipe-1", testPantryID, nil)
+
+	assert.NoError(t, err)
+	assert.False(t, report.Complete)
+	assert.Equal(t, 500.0, report.Ingredients[0].Short)
+	assert.Equal(t, 1.0, report.Ingredients[1].Short)
+}
+
+func TestCookRecipe_ScalesToServings(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMemberWithEntries(ctx, entity.PantryRoleEditor, []entity.PantryEntry{
+		{ID: "eggs", Name: "Eggs", IngredientID: "egg", Quantity: float64Ptr(12)},
+	})
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(cookingRecipe(), nil).Times(1)
+	expectTransaction(ctx)
+	mockPantryRepo.EXPECT().ConsumePantryEntry(ctx, testPantryID, "eggs", 6.0).Return(false, nil).Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryConsumed)
+
+	report, err := usecaseInstance.CookRecipe(ctx, "recipe-1", testPantryID, intPtr(4))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, *report.Recipe.Servings)
+	assert.Equal(t, "ml", *report.Ingredients[0].Unit)
+	assert.Equal(t, 1000.0, report.Ingredients[0].Short)
+}
+
+func TestCookRecipe_ScalingDeductsExactAmounts(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	recipe := &entity.Recipe{
+		ID:       "recipe-1",
+		Name:     "Bread",
+		Servings: intPtr(2),
+		Ingredients: []entity.RecipeIngredient{
+			{Name: "yeast", IngredientID: "yeast", Quantity: float64Ptr(0.5), Unit: stringPtr("g")},
+		},
+	}
+	expectMemberWithEntries(ctx, entity.PantryRoleEditor, []entity.PantryEntry{
+		{ID: "yeast", Name: "Yeast", IngredientID: "yeast", Quantity: float64Ptr(10), QuantityType: stringPtr("g")},
+	})
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(recipe, nil).Times(1)
+	expectTransaction(ctx)
+	// A quarter gram is taken, not the rounded amount shown by scaledRecipe.
+	mockPantryRepo.EXPECT().ConsumePantryEntry(ctx, testPantryID, "yeast", 0.25).Return(false, nil).Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryConsumed)
+
+	report, err := usecaseInstance.CookRecipe(ctx, "recipe-1", testPantryID, intPtr(1))
+
+	assert.NoError(t, err)
+	assert.True(t, report.Complete)
+}
+
+func TestCookRecipe_FailedDrawAbortsTransaction(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMemberWithEntries(ctx, entity.PantryRoleEditor, []entity.PantryEntry{
+		{ID: "milk", Name: "Milk", IngredientID: "milk", Quantity: float64Ptr(1), QuantityType: stringPtr("l")},
+		{ID: "eggs", Name: "Eggs", IngredientID: "egg", Quantity: float64Ptr(6)},
+	})
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(cookingRecipe(), nil).Times(1)
+	expectTransaction(ctx)
+	// The milk draw and its record are rolled back with the transaction.
+	mockPantryRepo.EXPECT().ConsumePantryEntry(ctx, testPantryID, "milk", 0.5).Return(false, nil).Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryConsumed)
+	mockPantryRepo.EXPECT().
+		ConsumePantryEntry(ctx, testPantryID, "eggs", 3.0).
+		Return(false, repository.ErrInsufficientQuantity).
+		Times(1)
+
+	_, err := usecaseInstance.CookRecipe(ctx, "recipe-1", testPantryID, nil)
+
+	assert.ErrorIs(t, err, repository.ErrInsufficientQuantity)
+}
+
+func TestCookRecipe_HistoryFailureAbortsTransaction(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMemberWithEntries(ctx, entity.PantryRoleEditor, []entity.PantryEntry{
+		{ID: "eggs", Name: "Eggs", IngredientID: "egg", Quantity: float64Ptr(12)},
+	})
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(cookingRecipe(), nil).Times(1)
+	expectTransaction(ctx)
+	mockPantryRepo.EXPECT().ConsumePantryEntry(ctx, testPantryID, "eggs", 3.0).Return(false, nil).Times(1)
+	mockHistoryRepo.EXPECT().AppendHistory(ctx, gomock.Any()).Return(assert.AnError).Times(1)
+
+	_, err := usecaseInstance.CookRecipe(ctx, "recipe-1", testPantryID, nil)
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestCookRecipe_ViewerForbidden(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleViewer)
+
+	_, err := usecaseInstance.CookRecipe(ctx, "recipe-1", testPantryID, nil)
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
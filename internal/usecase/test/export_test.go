@@ -0,0 +1,113 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/usecase"
+)
+
+func exportEntries() []entity.PantryEntry {
+	dairy := entity.CategoryDairy
+	expiration := time.Date(2030, 1, 15, 0, 0, 0, 0, time.UTC)
+	return []entity.PantryEntry{
+		{ID: "1", Name: "Milk", Quantity: float64Ptr(1.5), QuantityType: stringPtr("l"), Expiration: &expiration,
+			LocationID: stringPtr("fridge"), Category: &dairy, Tags: []string{"organic", "opened"}},
+		{ID: "2", Name: "Flour, plain"},
+	}
+}
+
+func TestExportPantry_CSV(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithLocationsAndEntries(ctx, exportEntries())
+
+	export, err := usecaseInstance.ExportPantry(ctx, testPantryID, usecase.ExportFormatCSV)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	assert.NoError(t, export.Write(&out))
+	assert.Equal(t, strings.Join([]string{
+		"id,name,quantity,unit,expiration,location,category,tags",
+		"1,Milk,1.5,l,2030-01-15T00:00:00Z,Fridge,DAIRY,organic;opened",
+		`2,"Flour, plain",,,,,,`,
+		"",
+	}, "\n"), out.String())
+	assert.Equal(t, "pantry-testPantryID.csv", export.Filename())
+}
+
+func TestExportPantry_JSON(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithLocationsAndEntries(ctx, exportEntries())
+
+	export, err := usecaseInstance.ExportPantry(ctx, testPantryID, usecase.ExportFormatJSON)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	assert.NoError(t, export.Write(&out))
+	var decoded struct {
+		ID        string                   `json:"id"`
+		Locations []entity.StorageLocation `json:"locations"`
+		Entries   []entity.PantryEntry     `json:"entries"`
+	}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, testPantryID, decoded.ID)
+	assert.Len(t, decoded.Locations, 2)
+	assert.Len(t, decoded.Entries, 2)
+	assert.Equal(t, "Milk", decoded.Entries[0].Name)
+}
+
+func TestExportPantry_Markdown(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithLocationsAndEntries(ctx, exportEntries())
+
+	export, err := usecaseInstance.ExportPantry(ctx, testPantryID, usecase.ExportFormatMarkdown)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	assert.NoError(t, export.Write(&out))
+	assert.Equal(t, strings.Join([]string{
+		"# Pantry",
+		"",
+		"## Fridge",
+		"",
+		"- [ ] Milk — 1.5 l (expires 2030-01-15)",
+		"",
+		"## Unassigned",
+		"",
+		"- [ ] Flour, plain",
+		"",
+	}, "\n"), out.String())
+}
+
+func TestExportPantry_Forbidden(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	_, err := usecaseInstance.ExportPantry(callerContext(""), testPantryID, usecase.ExportFormatCSV)
+
+	assert.ErrorIs(t, err, usecase.ErrUnauthenticated)
+}
+
+func TestParseExportFormat(t *testing.T) {
+	format, err := usecase.ParseExportFormat("MD")
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.ExportFormatMarkdown, format)
+
+	_, err = usecase.ParseExportFormat("xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,204 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/usecase"
+)
+
+// historyAction matches a *entity.HistoryRecord with the given action.
+type historyAction entity.HistoryAction
+
+func (a historyAction) Matches(x interface{}) bool {
+	record, ok := x.(*entity.HistoryRecord)
+	return ok && record.Action == entity.HistoryAction(a)
+}
+
+func (a historyAction) String() string {
+	return "history record with action " + string(a)
+}
+
+// expectHistory expects one audit record with the given action.
+func expectHistory(ctx context.Context, action entity.HistoryAction) {
+	mockHistoryRepo.EXPECT().AppendHistory(ctx, historyAction(action)).Return(nil).Times(1)
+}
+
+// expectPantryWithEntry makes testUserID an editor of a pantry holding one
+// entry with ID "1".
+func expectPantryWithEntry(ctx context.Context) {
+	entries := []entity.PantryEntry{
+		{ID: "1", Name: "milk", Quantity: float64Ptr(2), QuantityType: stringPtr("l"), Tags: []string{"organic"}},
+	}
+	mockPantryRepo.EXPECT().
+		GetPantry(ctx, testPantryID).
+		Return(&entity.Pantry{
+			ID:      testPantryID,
+			OwnerID: "ownerID",
+			Members: []entity.PantryMember{{UserID: testUserID, Role: entity.PantryRoleEditor}},
+			Entries: &entries,
+		}, nil).
+		Times(1)
+}
+
+func TestUpdatePantryEntry_RecordsBeforeAndAfter(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := context.WithValue(callerContext(testUserID), "requestID", "req-1")
+	patch := &entity.PantryEntryPatch{Quantity: float64Ptr(1), Tags: []string{"Opened"}}
+	expectPantryWithEntry(ctx)
+	mockPantryRepo.EXPECT().UpdatePantryEntry(ctx, testPantryID, "1", patch).Return(nil).Times(1)
+
+	var record *entity.HistoryRecord
+	mockHistoryRepo.EXPECT().
+		AppendHistory(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, r *entity.HistoryRecord) error {
+			record = r
+			return nil
+		}).
+		Times(1)
+
+	err := usecaseInstance.UpdatePantryEntry(ctx, testPantryID, "1", patch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.HistoryActionEntryUpdated, record.Action)
+	assert.Equal(t, testUserID, record.ActorID)
+	assert.Equal(t, "req-1", *record.RequestID)
+	assert.Equal(t, "1", *record.EntryID)
+	assert.Equal(t, 2.0, *record.Before.Quantity)
+	assert.Equal(t, []string{"organic"}, record.Before.Tags)
+	assert.Equal(t, 1.0, *record.After.Quantity)
+	assert.Equal(t, []string{"opened"}, record.After.Tags)
+	assert.Equal(t, "milk", record.After.Name)
+}
+
+func TestConsumePantryEntry_RecordsDepletion(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMemberWithEntries(ctx, entity.PantryRoleEditor, []entity.PantryEntry{{ID: "1", Name: "milk", Quantity: float64Ptr(1), QuantityType: stringPtr("l")}})
+	mockPantryRepo.EXPECT().ConsumePantryEntry(ctx, testPantryID, "1", 1.0).Return(true, nil).Times(1)
+
+	var record *entity.HistoryRecord
+	mockHistoryRepo.EXPECT().
+		AppendHistory(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, r *entity.HistoryRecord) error {
+			record = r
+			return nil
+		}).
+		Times(1)
+
+	_, err := usecaseInstance.ConsumePantryEntry(ctx, testPantryID, "1", 1, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.HistoryActionEntryConsumed, record.Action)
+	assert.Equal(t, 1.0, *record.Before.Quantity)
+	assert.Nil(t, record.After)
+	assert.Nil(t, record.RequestID)
+}
+
+func TestDeletePantryEntry_RecordsBefore(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithEntry(ctx)
+	mockPantryRepo.EXPECT().DeletePantryEntry(ctx, testPantryID, "1").Return(nil).Times(1)
+
+	var record *entity.HistoryRecord
+	mockHistoryRepo.EXPECT().
+		AppendHistory(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, r *entity.HistoryRecord) error {
+			record = r
+			return nil
+		}).
+		Times(1)
+
+	err := usecaseInstance.DeletePantryEntry(ctx, testPantryID, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.HistoryActionEntryDeleted, record.Action)
+	assert.Equal(t, "milk", record.Before.Name)
+	assert.Nil(t, record.After)
+}
+
+func TestDeletePantryEntry_FailureNotRecorded(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithEntry(ctx)
+	mockPantryRepo.EXPECT().DeletePantryEntry(ctx, testPantryID, "1").Return(assert.AnError).Times(1)
+
+	err := usecaseInstance.DeletePantryEntry(ctx, testPantryID, "1")
+
+	assert.Error(t, err)
+}
+
+func TestGetPantryHistory(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	since := time.Now().Add(-time.Hour)
+	entryID := "1"
+	expectMember(ctx, entity.PantryRoleViewer)
+	mockHistoryRepo.EXPECT().
+		GetHistory(ctx, testPantryID, &since, &entryID).
+		Return([]entity.HistoryRecord{{ID: "h1"}}, nil).
+		Times(1)
+
+	records, err := usecaseInstance.GetPantryHistory(ctx, testPantryID, &since, &entryID)
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestGetPantryHistory_DeletedPantryActor(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	entryID := "1"
+	mockPantryRepo.EXPECT().GetPantry(ctx, testPantryID).Return(nil, repository.ErrPantryNotFound).Times(1)
+	mockHistoryRepo.EXPECT().
+		GetHistory(ctx, testPantryID, nil, nil).
+		Return([]entity.HistoryRecord{
+			{ID: "h3", Action: entity.HistoryActionPantryDeleted, ActorID: testUserID},
+			{ID: "h2", Action: entity.HistoryActionEntryInserted, EntryID: &entryID, ActorID: "someone-else"},
+			{ID: "h1", Action: entity.HistoryActionPantryCreated, ActorID: testUserID},
+		}, nil).
+		Times(1)
+
+	records, err := usecaseInstance.GetPantryHistory(ctx, testPantryID, nil, &entryID)
+
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "h2", records[0].ID)
+	}
+}
+
+func TestGetPantryHistory_DeletedPantryStranger(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext("stranger")
+	mockPantryRepo.EXPECT().GetPantry(ctx, testPantryID).Return(nil, repository.ErrPantryNotFound).Times(1)
+	mockHistoryRepo.EXPECT().
+		GetHistory(ctx, testPantryID, nil, nil).
+		Return([]entity.HistoryRecord{{ID: "h1", Action: entity.HistoryActionPantryDeleted, ActorID: testUserID}}, nil).
+		Times(1)
+
+	records, err := usecaseInstance.GetPantryHistory(ctx, testPantryID, nil, nil)
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+	assert.Nil(t, records)
+}
@@ -0,0 +1,176 @@
+package test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/usecase"
+)
+
+func TestImportPantryEntries_CSV(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	csv := strings.Join([]string{
+		"name,quantity,unit,expiration,location,category,tags",
+		"Milk,2,l,2030-01-15,fridge,,Organic;Opened",
+		"Frozen peas,500,g,,Freezer,frozen,",
+	}, "\n")
+
+	expectPantryWithLocations(ctx)
+	mockPantryRepo.EXPECT().
+		InsertPantryEntries(ctx, testPantryID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entries []entity.PantryEntry) error {
+			assert.Len(t, entries, 2)
+			assert.Equal(t, "Milk", entries[0].Name)
+			assert.Equal(t, 2.0, *entries[0].Quantity)
+			assert.Equal(t, "fridge", *entries[0].LocationID)
+			assert.Equal(t, entity.CategoryDairy, *entries[0].Category)
+			assert.Equal(t, []string{"organic", "opened"}, entries[0].Tags)
+			assert.Equal(t, 2030, entries[0].Expiration.Year())
+			assert.Equal(t, "freezer", *entries[1].LocationID)
+			assert.Equal(t, entity.CategoryFrozen, *entries[1].Category)
+			return nil
+		}).
+		Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+
+	result, err := usecaseInstance.ImportPantryEntries(ctx, testPantryID, strings.NewReader(csv), entity.ImportFormatCSV, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Empty(t, result.Errors)
+}
+
+func TestImportPantryEntries_InvalidRowsWriteNothing(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	csv := strings.Join([]string{
+		"name,quantity,location,category",
+		"Milk,2,fridge,",
+		",1,,",
+		"Eggs,a dozen,,",
+		"Ice,1,garage,",
+		"Bread,1,,pastry",
+	}, "\n")
+	expectPantryWithLocations(ctx)
+
+	result, err := usecaseInstance.ImportPantryEntries(ctx, testPantryID, strings.NewReader(csv), entity.ImportFormatCSV, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Imported)
+	rows := []int{}
+	for _, rowErr := range result.Errors {
+		rows = append(rows, rowErr.Row)
+	}
+	assert.Equal(t, []int{2, 3, 4, 5}, rows)
+}
+
+func TestImportPantryEntries_DryRun(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	json := `[{"name": "Milk", "quantity": 1}, {"name": "Rice", "quantity": "lots"}, {"quantity": 2}]`
+	expectPantryWithLocations(ctx)
+
+	result, err := usecaseInstance.ImportPantryEntries(ctx, testPantryID, strings.NewReader(json), entity.ImportFormatJSON, true)
+
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 0, result.Imported)
+	assert.Len(t, result.Errors, 2)
+	assert.Equal(t, 2, result.Errors[0].Row)
+	assert.Equal(t, 3, result.Errors[1].Row)
+}
+
+func TestImportPantryEntries_JSONCategoryIgnoresCase(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	json := `[{"name": "Cheddar", "category": "dairy"}]`
+	expectPantryWithLocations(ctx)
+	mockPantryRepo.EXPECT().
+		InsertPantryEntries(ctx, testPantryID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entries []entity.PantryEntry) error {
+			if assert.Len(t, entries, 1) {
+				assert.Equal(t, entity.CategoryDairy, *entries[0].Category)
+			}
+			return nil
+		}).
+		Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+
+	result, err := usecaseInstance.ImportPantryEntries(ctx, testPantryID, strings.NewReader(json), entity.ImportFormatJSON, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	assert.Empty(t, result.Errors)
+}
+
+func TestImportPantryEntries_StopsPastRowLimit(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	inputs := map[entity.ImportFormat]string{
+		entity.ImportFormatCSV:  "name\n" + strings.Repeat("Milk\n", 1001),
+		entity.ImportFormatJSON: "[" + strings.Repeat(`{"name":"Milk"},`, 1001),
+	}
+
+	for format, input := range inputs {
+		expectPantryWithLocations(ctx)
+		// Anything after row 1001 fails the read, so reaching it would show.
+		r := io.MultiReader(strings.NewReader(input), iotest.ErrReader(assert.AnError))
+
+		_, err := usecaseInstance.ImportPantryEntries(ctx, testPantryID, r, format, false)
+
+		assert.EqualError(t, err, "an import can contain at most 1000 rows", string(format))
+	}
+}
+
+func TestImportPantryEntries_FileTooLarge(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithLocations(ctx)
+
+	input := "name\n" + strings.Repeat("a", 5<<20) + "\n"
+	_, err := usecaseInstance.ImportPantryEntries(ctx, testPantryID, strings.NewReader(input), entity.ImportFormatCSV, false)
+
+	assert.EqualError(t, err, "an import file can be at most 4 MiB")
+}
+
+func TestImportPantryEntries_ViewerForbidden(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleViewer)
+
+	_, err := usecaseInstance.ImportPantryEntries(ctx, testPantryID, strings.NewReader("name\nMilk\n"), entity.ImportFormatCSV, false)
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestImportFormatFromFilename(t *testing.T) {
+	format, err := usecase.ImportFormatFromFilename("pantry.JSON")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ImportFormatJSON, format)
+
+	_, err = usecase.ImportFormatFromFilename("pantry.xlsx")
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+)
+
+// expectPantryWithLocations makes testUserID an editor of a pantry with a
+// fridge and a freezer.
+func expectPantryWithLocations(ctx context.Context) {
+	expectPantryWithLocationsAndEntries(ctx, nil)
+}
+
+// expectPantryWithLocationsAndEntries is expectPantryWithLocations with the
+// given entries stored in the pantry.
+func expectPantryWithLocationsAndEntries(ctx context.Context, entries []entity.PantryEntry) {
+	pantry := &entity.Pantry{
+		ID:      testPantryID,
+		Members: []entity.PantryMember{{UserID: testUserID, Role: entity.PantryRoleEditor}},
+		Locations: []entity.StorageLocation{
+			{ID: "fridge", Name: "Fridge", Kind: entity.LocationKindFridge},
+			{ID: "freezer", Name: "Freezer", Kind: entity.LocationKindFreezer},
+		},
+	}
+	if entries != nil {
+		pantry.Entries = &entries
+	}
+	mockPantryRepo.EXPECT().
+		GetPantry(ctx, testPantryID).
+		Return(pantry, nil).
+		Times(1)
+}
+
+func TestGetPantryEntriesByLocation(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithLocationsAndEntries(ctx, []entity.PantryEntry{
+		{ID: "1", Name: "Milk", LocationID: stringPtr("fridge")},
+		{ID: "2", Name: "Flour"},
+		{ID: "3", Name: "Butter", LocationID: stringPtr("fridge")},
+		{ID: "4", Name: "Peas", LocationID: stringPtr("removed")},
+	})
+
+	groups, err := usecaseInstance.GetPantryEntriesByLocation(ctx, testPantryID)
+
+	assert.NoError(t, err)
+	if assert.Len(t, groups, 3) {
+		assert.Equal(t, "fridge", groups[0].Location.ID)
+		assert.Len(t, groups[0].Entries, 2)
+		assert.Equal(t, "freezer", groups[1].Location.ID)
+		assert.Empty(t, groups[1].Entries)
+		assert.Nil(t, groups[2].Location)
+		assert.Len(t, groups[2].Entries, 2)
+	}
+}
+
+func TestFindPantryEntries_ByLocation(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithLocations(ctx)
+	filter := &entity.PantryEntryFilter{LocationID: stringPtr("fridge")}
+	mockPantryRepo.EXPECT().
+		FindPantryEntries(ctx, testPantryID, filter).
+		Return([]entity.PantryEntry{{ID: "1", Name: "Milk", LocationID: stringPtr("fridge")}}, nil).
+		Times(1)
+
+	entries, err := usecaseInstance.FindPantryEntries(ctx, testPantryID, filter)
+
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "Milk", entries[0].Name)
+	}
+}
+
+func TestMovePantryEntry(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithLocations(ctx)
+	mockPantryRepo.EXPECT().MovePantryEntry(ctx, testPantryID, "1", "freezer").Return(nil).Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryUpdated)
+
+	err := usecaseInstance.MovePantryEntry(ctx, testPantryID, "1", "freezer")
+
+	assert.NoError(t, err)
+}
+
+func TestRemoveStorageLocation_RecordsUnassignedEntries(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	entries := []entity.PantryEntry{
+		{ID: "1", Name: "Milk", LocationID: stringPtr("fridge")},
+		{ID: "2", Name: "Peas", LocationID: stringPtr("freezer")},
+	}
+	mockPantryRepo.EXPECT().
+		GetPantry(ctx, testPantryID).
+		Return(&entity.Pantry{
+			ID:        testPantryID,
+			Members:   []entity.PantryMember{{UserID: testUserID, Role: entity.PantryRoleEditor}},
+			Locations: []entity.StorageLocation{{ID: "fridge", Name: "Fridge", Kind: entity.LocationKindFridge}},
+			Entries:   &entries,
+		}, nil).
+		Times(1)
+	mockPantryRepo.EXPECT().RemoveStorageLocation(ctx, testPantryID, "fridge").Return(nil).Times(1)
+
+	var record *entity.HistoryRecord
+	mockHistoryRepo.EXPECT().
+		AppendHistory(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, r *entity.HistoryRecord) error {
+			record = r
+			return nil
+		}).
+		Times(1)
+
+	err := usecaseInstance.RemoveStorageLocation(ctx, testPantryID, "fridge")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.HistoryActionEntryUpdated, record.Action)
+	assert.Equal(t, "1", *record.EntryID)
+	assert.Equal(t, "fridge", *record.Before.LocationID)
+	assert.Nil(t, record.After.LocationID)
+}
+
+func TestMovePantryEntry_UnknownLocation(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithLocations(ctx)
+
+	err := usecaseInstance.MovePantryEntry(ctx, testPantryID, "1", "cellar")
+
+	assert.ErrorIs(t, err, repository.ErrLocationNotFound)
+}
+
+func TestInsertPantryEntry_UnknownLocation(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithLocations(ctx)
+
+	err := usecaseInstance.InsertPantryEntry(ctx, testPantryID, &entity.PantryEntryInput{
+		Name:       "Milk",
+		LocationID: stringPtr("cellar"),
+	})
+
+	assert.ErrorIs(t, err, repository.ErrLocationNotFound)
+}
+
+func TestAddStorageLocation_DuplicateName(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithLocations(ctx)
+
+	location, err := usecaseInstance.AddStorageLocation(ctx, testPantryID, &entity.StorageLocationInput{
+		Name: "fridge",
+		Kind: entity.LocationKindFridge,
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, location)
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+)
+
+func TestPlanMeal(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(&entity.Recipe{ID: "recipe-1"}, nil).Times(1)
+	mockPlanRepo.EXPECT().CreateMealPlan(ctx, gomock.Any()).Return(nil).Times(1)
+
+	evening := time.Date(2024, 3, 14, 19, 30, 0, 0, time.UTC)
+	plan, err := usecaseInstance.PlanMeal(ctx, testPantryID, &entity.MealPlanInput{
+		Date:     evening,
+		Slot:     entity.MealSlotDinner,
+		RecipeID: "recipe-1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC), plan.Date)
+	assert.Equal(t, testUserID, plan.CreatedBy)
+	assert.Equal(t, "recipe-1", plan.Recipe.ID)
+}
+
+func TestGetMealPlans_InvalidRange(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	from := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	_, err := usecaseInstance.GetMealPlans(callerContext(testUserID), testPantryID, from, from.AddDate(0, 0, -1))
+
+	assert.Error(t, err)
+}
+
+func TestGetMealPlans(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	from := time.Date(2024, 3, 11, 8, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 17, 22, 0, 0, 0, time.UTC)
+	expectMemberWithEntries(ctx, entity.PantryRoleViewer, []entity.PantryEntry{{ID: "1", Name: "flour"}})
+	mockPlanRepo.EXPECT().
+		GetMealPlans(ctx, testPantryID, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)).
+		Return([]entity.MealPlan{
+			{ID: "plan-1", PantryID: testPantryID, RecipeID: "recipe-1"},
+			{ID: "plan-2", PantryID: testPantryID, RecipeID: "recipe-1"},
+		}, nil).
+		Times(1)
+	// Both plans share one recipe lookup.
+	mockRecipeRepo.EXPECT().
+		GetRecipesByIDs(ctx, []string{"recipe-1"}).
+		Return([]entity.Recipe{{
+			ID: "recipe-1",
+			Ingredients: []entity.RecipeIngredient{
+				{Name: "flour", Quantity: float64Ptr(2), Unit: stringPtr("cups")},
+				{Name: "eggs", Quantity: float64Ptr(2)},
+				{Name: "milk", Quantity: float64Ptr(1), Unit: stringPtr("cup")},
+			},
+		}}, nil).
+		Times(1)
+
+	plans, err := usecaseInstance.GetMealPlans(ctx, testPantryID, from, to)
+
+	assert.NoError(t, err)
+	if assert.Len(t, plans, 2) {
+		for _, plan := range plans {
+			assert.Equal(t, "recipe-1", plan.Recipe.ID)
+			assert.Equal(t, []string{"eggs", "milk"}, plan.MissingIngredients)
+		}
+	}
+}
+
+func TestGetMealPlans_MissingRecipe(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	day := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	expectMember(ctx, entity.PantryRoleViewer)
+	mockPlanRepo.EXPECT().
+		GetMealPlans(ctx, testPantryID, day, day).
+		Return([]entity.MealPlan{{ID: "plan-1", PantryID: testPantryID, RecipeID: "gone"}}, nil).
+		Times(1)
+	mockRecipeRepo.EXPECT().GetRecipesByIDs(ctx, []string{"gone"}).Return(nil, nil).Times(1)
+
+	plans, err := usecaseInstance.GetMealPlans(ctx, testPantryID, day, day)
+
+	assert.ErrorIs(t, err, repository.ErrRecipeNotFound)
+	assert.Nil(t, plans)
+}
+
+func TestRemoveMealPlan_ViewerForbidden(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockPlanRepo.EXPECT().
+		GetMealPlan(ctx, "plan-1").
+		Return(&entity.MealPlan{ID: "plan-1", PantryID: testPantryID}, nil).
+		Times(1)
+	expectMember(ctx, entity.PantryRoleViewer)
+
+	err := usecaseInstance.RemoveMealPlan(ctx, "plan-1")
+
+	assert.Error(t, err)
+}
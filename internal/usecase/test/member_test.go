@@ -0,0 +1,208 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/usecase"
+)
+
+func TestGetAllPantryEntries_Unauthenticated(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	result, err := usecaseInstance.GetAllPantryEntries(context.Background(), testPantryID)
+
+	assert.ErrorIs(t, err, usecase.ErrUnauthenticated)
+	assert.Nil(t, result)
+}
+
+func TestGetAllPantryEntries_NotAMember(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext("stranger")
+	expectMember(ctx, entity.PantryRoleViewer)
+
+	result, err := usecaseInstance.GetAllPantryEntries(ctx, testPantryID)
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+	assert.Nil(t, result)
+}
+
+func TestInsertPantryEntry_ViewerForbidden(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleViewer)
+
+	err := usecaseInstance.InsertPantryEntry(ctx, testPantryID, &entity.PantryEntryInput{Name: "Milk"})
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestGetAllPantryEntries_LegacyOwner(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockPantryRepo.EXPECT().
+		GetPantry(ctx, testPantryID).
+		Return(&entity.Pantry{ID: testPantryID, OwnerID: testUserID}, nil).
+		Times(1)
+
+	_, err := usecaseInstance.GetAllPantryEntries(ctx, testPantryID)
+
+	assert.NoError(t, err)
+}
+
+func TestRemoveUserPantry_EditorForbidden(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleEditor)
+
+	err := usecaseInstance.RemoveUserPantry(ctx, testUserID, testPantryID)
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestRemoveUserPantry_DetachesAllMembers(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext("ownerID")
+	expectMember(ctx, entity.PantryRoleViewer)
+	expectTransaction(ctx)
+	mockUserRepo.EXPECT().DeletePantryFromUser(ctx, "ownerID", testPantryID).Return(nil).Times(1)
+	mockUserRepo.EXPECT().DeletePantryFromUser(ctx, testUserID, testPantryID).Return(nil).Times(1)
+	mockPantryRepo.EXPECT().DeletePantry(ctx, testPantryID).Return(nil).Times(1)
+	expectHistory(ctx, entity.HistoryActionPantryDeleted)
+
+	err := usecaseInstance.RemoveUserPantry(ctx, "ownerID", testPantryID)
+
+	assert.NoError(t, err)
+}
+
+func TestInvitePantryMember(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext("ownerID")
+	expectMember(ctx, entity.PantryRoleViewer)
+	mockInviteRepo.EXPECT().CreateInvite(ctx, gomock.Any()).Return(nil).Times(1)
+
+	invite, err := usecaseInstance.InvitePantryMember(ctx, testPantryID, " Jane.Doe@Example.com ", entity.PantryRoleEditor)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, invite.Code)
+	assert.Equal(t, "jane.doe@example.com", invite.Email)
+	assert.Equal(t, "ownerID", invite.InvitedBy)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), invite.ExpiresAt, time.Minute)
+}
+
+func TestInvitePantryMember_CannotGrantOwner(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	invite, err := usecaseInstance.InvitePantryMember(callerContext("ownerID"), testPantryID, "jane@example.com", entity.PantryRoleOwner)
+
+	assert.Error(t, err)
+	assert.Nil(t, invite)
+}
+
+func TestAcceptPantryInvite(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext("newUserID")
+	mockInviteRepo.EXPECT().GetInvite(ctx, "code").Return(&entity.PantryInvite{
+		Code:     "code",
+		PantryID: testPantryID,
+		Email:    "jane@example.com",
+		Role:     entity.PantryRoleViewer,
+	}, nil).Times(1)
+	mockUserRepo.EXPECT().GetUser(ctx, "newUserID").Return(&entity.User{ID: "newUserID", Email: "Jane@example.com"}, nil).Times(1)
+	expectTransaction(ctx)
+	expectMember(ctx, entity.PantryRoleViewer)
+	mockInviteRepo.EXPECT().AcceptInvite(ctx, "code", "newUserID", gomock.Any()).Return(nil).Times(1)
+	mockPantryRepo.EXPECT().
+		AddPantryMember(ctx, testPantryID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, member *entity.PantryMember) error {
+			assert.Equal(t, "newUserID", member.UserID)
+			assert.Equal(t, entity.PantryRoleViewer, member.Role)
+			return nil
+		}).
+		Times(1)
+	mockUserRepo.EXPECT().UpdateUserWithPantry(ctx, "newUserID", testPantryID).Return(nil).Times(1)
+
+	err := usecaseInstance.AcceptPantryInvite(ctx, "code")
+
+	assert.NoError(t, err)
+}
+
+func TestAcceptPantryInvite_WrongEmail(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext("newUserID")
+	mockInviteRepo.EXPECT().GetInvite(ctx, "code").Return(&entity.PantryInvite{
+		Code:     "code",
+		PantryID: testPantryID,
+		Email:    "jane@example.com",
+		Role:     entity.PantryRoleViewer,
+	}, nil).Times(1)
+	mockUserRepo.EXPECT().GetUser(ctx, "newUserID").Return(&entity.User{ID: "newUserID", Email: "jim@example.com"}, nil).Times(1)
+
+	err := usecaseInstance.AcceptPantryInvite(ctx, "code")
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestRevokePantryAccess_OwnerCannotBeRevoked(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext("ownerID")
+	expectMember(ctx, entity.PantryRoleViewer)
+
+	err := usecaseInstance.RevokePantryAccess(ctx, testPantryID, "ownerID")
+
+	assert.Error(t, err)
+}
+
+func TestRevokePantryAccess_MemberLeaves(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleViewer)
+	mockPantryRepo.EXPECT().RemovePantryMember(ctx, testPantryID, testUserID).Return(nil).Times(1)
+	mockUserRepo.EXPECT().DeletePantryFromUser(ctx, testUserID, testPantryID).Return(nil).Times(1)
+
+	err := usecaseInstance.RevokePantryAccess(ctx, testPantryID, testUserID)
+
+	assert.NoError(t, err)
+}
+
+func TestRemoveUserPantry_MissingPantryNotRecorded(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext("ownerID")
+	expectMember(ctx, entity.PantryRoleViewer)
+	expectTransaction(ctx)
+	mockPantryRepo.EXPECT().DeletePantry(ctx, testPantryID).Return(repository.ErrPantryNotFound).Times(1)
+
+	err := usecaseInstance.RemoveUserPantry(ctx, "ownerID", testPantryID)
+
+	assert.ErrorIs(t, err, repository.ErrPantryNotFound)
+}
@@ -0,0 +1,173 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+// expectPantryWithEntries makes testUserID an editor of a pantry holding the
+// given entries.
+func expectPantryWithEntries(ctx context.Context, entries []entity.PantryEntry) {
+	mockPantryRepo.EXPECT().
+		GetPantry(ctx, testPantryID).
+		Return(&entity.Pantry{
+			ID:      testPantryID,
+			Members: []entity.PantryMember{{UserID: testUserID, Role: entity.PantryRoleEditor}},
+			Locations: []entity.StorageLocation{
+				{ID: "shelf", Name: "Shelf", Kind: entity.LocationKindShelf},
+				{ID: "fridge", Name: "Fridge", Kind: entity.LocationKindFridge},
+			},
+			Entries: &entries,
+		}, nil).
+		Times(1)
+}
+
+func TestInsertOrMergePantryEntry_IncrementsCompatibleEntry(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectTransaction(ctx)
+	expectPantryWithEntries(ctx, []entity.PantryEntry{
+		{ID: "1", Name: "Flour", Quantity: float64Ptr(1), QuantityType: stringPtr("kg")},
+	})
+	mockPantryRepo.EXPECT().IncrementPantryEntry(ctx, testPantryID, "1", 0.5, gomock.Any()).Return(nil).Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryUpdated)
+
+	entry, merged, err := usecaseInstance.InsertOrMergePantryEntry(ctx, testPantryID, &entity.PantryEntryInput{
+		Name:         " flour ",
+		Quantity:     float64Ptr(500),
+		QuantityType: stringPtr("g"),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, merged)
+	assert.Equal(t, "1", entry.ID)
+	assert.Equal(t, 1.5, *entry.Quantity)
+}
+
+func TestInsertOrMergePantryEntry_IncompatibleUnitInserts(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectTransaction(ctx)
+	expectPantryWithEntries(ctx, []entity.PantryEntry{
+		{ID: "1", Name: "Eggs", Quantity: float64Ptr(6), QuantityType: stringPtr("pieces")},
+	})
+	mockPantryRepo.EXPECT().InsertPantryEntry(ctx, testPantryID, gomock.Any()).Return(nil).Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+
+	_, merged, err := usecaseInstance.InsertOrMergePantryEntry(ctx, testPantryID, &entity.PantryEntryInput{
+		Name:         "eggs",
+		Quantity:     float64Ptr(1),
+		QuantityType: stringPtr("carton"),
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, merged)
+}
+
+func TestInsertOrMergePantryEntry_OtherLocationInserts(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectTransaction(ctx)
+	expectPantryWithEntries(ctx, []entity.PantryEntry{
+		{ID: "1", Name: "Butter", Quantity: float64Ptr(250), QuantityType: stringPtr("g"), LocationID: stringPtr("shelf")},
+	})
+	mockPantryRepo.EXPECT().InsertPantryEntry(ctx, testPantryID, gomock.Any()).Return(nil).Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+
+	_, merged, err := usecaseInstance.InsertOrMergePantryEntry(ctx, testPantryID, &entity.PantryEntryInput{
+		Name:         "Butter",
+		Quantity:     float64Ptr(250),
+		QuantityType: stringPtr("g"),
+		LocationID:   stringPtr("fridge"),
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, merged)
+}
+
+func TestInsertOrMergePantryEntry_NoLocationSkipsLocatedEntries(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectTransaction(ctx)
+	expectPantryWithEntries(ctx, []entity.PantryEntry{
+		{ID: "1", Name: "Butter", Quantity: float64Ptr(250), QuantityType: stringPtr("g"), LocationID: stringPtr("fridge")},
+	})
+	mockPantryRepo.EXPECT().InsertPantryEntry(ctx, testPantryID, gomock.Any()).Return(nil).Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+
+	_, merged, err := usecaseInstance.InsertOrMergePantryEntry(ctx, testPantryID, &entity.PantryEntryInput{
+		Name:         "Butter",
+		Quantity:     float64Ptr(250),
+		QuantityType: stringPtr("g"),
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, merged)
+}
+
+func TestMergeDuplicateEntries(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	soon := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := soon.AddDate(0, 1, 0)
+	expectPantryWithEntries(ctx, []entity.PantryEntry{
+		{ID: "1", Name: "Flour", Quantity: float64Ptr(1), QuantityType: stringPtr("kg"), Expiration: &later, Tags: []string{"baking"}},
+		{ID: "2", Name: "Rice", Quantity: float64Ptr(2), QuantityType: stringPtr("kg")},
+		{ID: "3", Name: "flour", Quantity: float64Ptr(250), QuantityType: stringPtr("g"), Expiration: &soon, Tags: []string{"organic"}},
+		{ID: "4", Name: "FLOUR", Quantity: float64Ptr(2), QuantityType: stringPtr("bags")},
+		{ID: "5", Name: "Rice", Quantity: float64Ptr(1), QuantityType: stringPtr("kg"), LocationID: stringPtr("fridge")},
+	})
+	expectTransaction(ctx)
+	mockPantryRepo.EXPECT().
+		MergePantryEntries(ctx, testPantryID, gomock.Any(), []string{"3"}).
+		DoAndReturn(func(_ context.Context, _ string, keep *entity.PantryEntry, _ []string) error {
+			assert.Equal(t, "1", keep.ID)
+			assert.Equal(t, 1.25, *keep.Quantity)
+			assert.Equal(t, soon, *keep.Expiration)
+			assert.Equal(t, []string{"baking", "organic"}, keep.Tags)
+			return nil
+		}).
+		Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryUpdated)
+	expectHistory(ctx, entity.HistoryActionEntryDeleted)
+
+	report, err := usecaseInstance.MergeDuplicateEntries(ctx, testPantryID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Removed)
+	assert.Len(t, report.Groups, 1)
+	assert.Equal(t, []string{"3"}, report.Groups[0].MergedEntryIDs)
+}
+
+func TestMergeDuplicateEntries_FailedMergeRecordsNothing(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectPantryWithEntries(ctx, []entity.PantryEntry{
+		{ID: "1", Name: "Flour", Quantity: float64Ptr(1), QuantityType: stringPtr("kg")},
+		{ID: "2", Name: "flour", Quantity: float64Ptr(500), QuantityType: stringPtr("g")},
+	})
+	expectTransaction(ctx)
+	mockPantryRepo.EXPECT().MergePantryEntries(ctx, testPantryID, gomock.Any(), []string{"2"}).Return(assert.AnError).Times(1)
+
+	_, err := usecaseInstance.MergeDuplicateEntries(ctx, testPantryID)
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -10,17 +10,30 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
 	m "github.com/thisausername99/pantry_butler/internal/mocks"
 	"github.com/thisausername99/pantry_butler/internal/usecase"
 )
 
-var testPantryID = "testPantryID"
+var (
+	testPantryID = "testPantryID"
+	testUserID   = "testUserID"
+)
 
 // Global test variables
 var (
 	mockCtrl        *gomock.Controller
 	mockPantryRepo  *m.MockPantryRepository
 	mockRecipeRepo  *m.MockRecipeRepository
+	mockUserRepo    *m.MockUserRepository
+	mockInviteRepo  *m.MockInviteRepository
+	mockProductRepo *m.MockProductRepository
+	mockListRepo    *m.MockShoppingListRepository
+	mockPlanRepo    *m.MockMealPlanRepository
+	mockHistoryRepo *m.MockHistoryRepository
+	mockRatingRepo  *m.MockRatingRepository
+	mockSubstRepo   *m.MockSubstitutionRepository
+	mockTransactor  *m.MockTransactor
 	usecaseInstance *usecase.Usecase
 )
 
@@ -29,16 +42,64 @@ func setupTest(t *testing.T) {
 	mockCtrl = gomock.NewController(t)
 	mockPantryRepo = m.NewMockPantryRepository(mockCtrl)
 	mockRecipeRepo = m.NewMockRecipeRepository(mockCtrl)
+	mockUserRepo = m.NewMockUserRepository(mockCtrl)
+	mockInviteRepo = m.NewMockInviteRepository(mockCtrl)
+	mockProductRepo = m.NewMockProductRepository(mockCtrl)
+	mockListRepo = m.NewMockShoppingListRepository(mockCtrl)
+	mockPlanRepo = m.NewMockMealPlanRepository(mockCtrl)
+	mockHistoryRepo = m.NewMockHistoryRepository(mockCtrl)
+	mockRatingRepo = m.NewMockRatingRepository(mockCtrl)
+	mockSubstRepo = m.NewMockSubstitutionRepository(mockCtrl)
+	mockTransactor = m.NewMockTransactor(mockCtrl)
 
 	usecaseInstance = &usecase.Usecase{
 		RepoWrapper: usecase.RepoWrapper{
-			PantryRepo: mockPantryRepo,
-			RecipeRepo: mockRecipeRepo,
+			PantryRepo:       mockPantryRepo,
+			RecipeRepo:       mockRecipeRepo,
+			UserRepo:         mockUserRepo,
+			InviteRepo:       mockInviteRepo,
+			ProductRepo:      mockProductRepo,
+			ShoppingListRepo: mockListRepo,
+			MealPlanRepo:     mockPlanRepo,
+			HistoryRepo:      mockHistoryRepo,
+			RatingRepo:       mockRatingRepo,
+			SubstitutionRepo: mockSubstRepo,
+			Transactor:       mockTransactor,
 		},
 		Logger: zap.NewNop(),
 	}
 }
 
+// callerContext returns a context carrying the requesting user, as the HTTP
+// middleware sets it.
+func callerContext(userID string) context.Context {
+	return context.WithValue(context.Background(), "userID", userID)
+}
+
+// expectMember makes testUserID hold role in testPantryID.
+func expectMember(ctx context.Context, role entity.PantryRole) {
+	expectMemberWithEntries(ctx, role, nil)
+}
+
+// expectMemberWithEntries is expectMember for a pantry holding entries.
+func expectMemberWithEntries(ctx context.Context, role entity.PantryRole, entries []entity.PantryEntry) {
+	pantry := &entity.Pantry{
+		ID:      testPantryID,
+		OwnerID: "ownerID",
+		Members: []entity.PantryMember{
+			{UserID: "ownerID", Role: entity.PantryRoleOwner},
+			{UserID: testUserID, Role: role},
+		},
+	}
+	if entries != nil {
+		pantry.Entries = &entries
+	}
+	mockPantryRepo.EXPECT().
+		GetPantry(ctx, testPantryID).
+		Return(pantry, nil).
+		Times(1)
+}
+
 // teardownTest cleans up after tests
 func teardownTest() {
 	if mockCtrl != nil {
@@ -67,11 +128,8 @@ func TestGetAllPantryEntries(t *testing.T) {
 	}
 
 	// Set up expectations
-	ctx := context.Background()
-	mockPantryRepo.EXPECT().
-		GetPantryEntries(ctx, testPantryID).
-		Return(expectedEntries, nil).
-		Times(1)
+	ctx := callerContext(testUserID)
+	expectMemberWithEntries(ctx, entity.PantryRoleViewer, expectedEntries)
 
 	// Execute test
 	result, err := usecaseInstance.GetAllPantryEntries(ctx, testPantryID)
@@ -89,10 +147,10 @@ func TestGetAllPantryEntries_Error(t *testing.T) {
 	defer teardownTest()
 
 	// Set up expectations for error case
-	ctx := context.Background()
+	ctx := callerContext(testUserID)
 	expectedError := assert.AnError
 	mockPantryRepo.EXPECT().
-		GetPantryEntries(ctx, testPantryID).
+		GetPantry(ctx, testPantryID).
 		Return(nil, expectedError).
 		Times(1)
 
@@ -110,11 +168,8 @@ func TestGetAllPantryEntries_Empty(t *testing.T) {
 	defer teardownTest()
 
 	// Set up expectations for empty result
-	ctx := context.Background()
-	mockPantryRepo.EXPECT().
-		GetPantryEntries(ctx, testPantryID).
-		Return([]entity.PantryEntry{}, nil).
-		Times(1)
+	ctx := callerContext(testUserID)
+	expectMemberWithEntries(ctx, entity.PantryRoleViewer, []entity.PantryEntry{})
 
 	// Execute test
 	result, err := usecaseInstance.GetAllPantryEntries(ctx, testPantryID)
@@ -125,11 +180,261 @@ func TestGetAllPantryEntries_Empty(t *testing.T) {
 	assert.Len(t, result, 0)
 }
 
+func TestUpdatePantryEntry(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleEditor)
+	patch := &entity.PantryEntryPatch{Quantity: float64Ptr(2)}
+	mockPantryRepo.EXPECT().
+		UpdatePantryEntry(ctx, testPantryID, "1", patch).
+		Return(nil).
+		Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryUpdated)
+
+	err := usecaseInstance.UpdatePantryEntry(ctx, testPantryID, "1", patch)
+
+	assert.NoError(t, err)
+}
+
+func TestUpdatePantryEntry_RenameResolvesIngredient(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockPantryRepo.EXPECT().
+		UpdatePantryEntry(ctx, testPantryID, "1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, patch *entity.PantryEntryPatch) error {
+			if assert.NotNil(t, patch.IngredientID) {
+				assert.Equal(t, "black_pepper", *patch.IngredientID)
+			}
+			return nil
+		}).
+		Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryUpdated)
+
+	err := usecaseInstance.UpdatePantryEntry(ctx, testPantryID, "1", &entity.PantryEntryPatch{Name: stringPtr("Ground Black Pepper")})
+
+	assert.NoError(t, err)
+}
+
+func TestUpdatePantryEntry_NotFound(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleEditor)
+	patch := &entity.PantryEntryPatch{Quantity: float64Ptr(2)}
+	mockPantryRepo.EXPECT().
+		UpdatePantryEntry(ctx, testPantryID, "missing", patch).
+		Return(repository.ErrPantryEntryNotFound).
+		Times(1)
+
+	err := usecaseInstance.UpdatePantryEntry(ctx, testPantryID, "missing", patch)
+
+	assert.ErrorIs(t, err, repository.ErrPantryEntryNotFound)
+}
+
+func TestInsertPantryEntry_DefaultsExpirationFromShelfLife(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleEditor)
+	before := time.Now()
+	mockPantryRepo.EXPECT().
+		InsertPantryEntry(ctx, testPantryID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entry *entity.PantryEntry) error {
+			assert.NotEmpty(t, entry.ID)
+			if assert.NotNil(t, entry.Expiration) {
+				assert.WithinDuration(t, before.AddDate(0, 0, 7), *entry.Expiration, time.Minute)
+			}
+			return nil
+		}).
+		Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+
+	err := usecaseInstance.InsertPantryEntry(ctx, testPantryID, &entity.PantryEntryInput{Name: "Milk"})
+
+	assert.NoError(t, err)
+}
+
+func TestInsertPantryEntry_InfersCategoryAndNormalizesTags(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockPantryRepo.EXPECT().
+		InsertPantryEntry(ctx, testPantryID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entry *entity.PantryEntry) error {
+			if assert.NotNil(t, entry.Category) {
+				assert.Equal(t, entity.CategoryDairy, *entry.Category)
+			}
+			assert.Equal(t, []string{"breakfast", "organic"}, entry.Tags)
+			return nil
+		}).
+		Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+
+	err := usecaseInstance.InsertPantryEntry(ctx, testPantryID, &entity.PantryEntryInput{
+		Name: "Greek Yogurt",
+		Tags: []string{" Breakfast", "organic", "breakfast", ""},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestTagPantryEntry_RequiresTags(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	err := usecaseInstance.TagPantryEntry(callerContext(testUserID), testPantryID, "1", []string{" "})
+
+	assert.Error(t, err)
+}
+
+func TestInsertPantryEntry_KeepsSuppliedExpiration(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectMember(ctx, entity.PantryRoleEditor)
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockPantryRepo.EXPECT().
+		InsertPantryEntry(ctx, testPantryID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entry *entity.PantryEntry) error {
+			assert.Equal(t, &expiration, entry.Expiration)
+			return nil
+		}).
+		Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+
+	err := usecaseInstance.InsertPantryEntry(ctx, testPantryID, &entity.PantryEntryInput{
+		Name:       "Milk",
+		Expiration: &expiration,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestGetExpiringEntries(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	entries := []entity.PantryEntry{
+		{ID: "1", Name: "Flour", Expiration: timePtr(time.Now().AddDate(0, 0, 300))},
+		{ID: "2", Name: "Milk", Expiration: timePtr(time.Now().Add(50 * time.Hour))},
+		{ID: "3", Name: "Salt"},
+		{ID: "4", Name: "Yogurt", Expiration: timePtr(time.Now().Add(-36 * time.Hour))},
+		{ID: "5", Name: "Bread", Expiration: timePtr(time.Now().Add(26 * time.Hour))},
+		{ID: "6", Name: "Cream", Expiration: timePtr(time.Now().Add(-12 * time.Hour))},
+	}
+	expectMemberWithEntries(ctx, entity.PantryRoleViewer, entries)
+
+	result, err := usecaseInstance.GetExpiringEntries(ctx, testPantryID, 7)
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 4) {
+		assert.Equal(t, "Yogurt", result[0].Entry.Name)
+		assert.True(t, result[0].Expired)
+		assert.Equal(t, -2, result[0].DaysUntilExpiration)
+		// Expired hours ago counts as a day ago, not as expiring today.
+		assert.Equal(t, "Cream", result[1].Entry.Name)
+		assert.Equal(t, -1, result[1].DaysUntilExpiration)
+		assert.Equal(t, "Bread", result[2].Entry.Name)
+		assert.Equal(t, 1, result[2].DaysUntilExpiration)
+		assert.False(t, result[2].Expired)
+		assert.Equal(t, "Milk", result[3].Entry.Name)
+		assert.Equal(t, 2, result[3].DaysUntilExpiration)
+	}
+}
+
+func TestGetExpiringEntries_NegativeWindow(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	result, err := usecaseInstance.GetExpiringEntries(context.Background(), testPantryID, -1)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestConsumePantryEntry_ConvertsUnit(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	entries := []entity.PantryEntry{
+		{ID: "1", Name: "Flour", Quantity: float64Ptr(2), QuantityType: stringPtr("kg")},
+	}
+	expectMemberWithEntries(ctx, entity.PantryRoleEditor, entries)
+	mockPantryRepo.EXPECT().
+		ConsumePantryEntry(ctx, testPantryID, "1", 0.25).
+		Return(false, nil).
+		Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryConsumed)
+
+	result, err := usecaseInstance.ConsumePantryEntry(ctx, testPantryID, "1", 250, stringPtr("g"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, result.Consumed)
+	assert.Equal(t, "kg", *result.QuantityType)
+	assert.False(t, result.Depleted)
+}
+
+func TestConsumePantryEntry_Insufficient(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	entries := []entity.PantryEntry{
+		{ID: "1", Name: "Apples", Quantity: float64Ptr(2)},
+	}
+	expectMemberWithEntries(ctx, entity.PantryRoleEditor, entries)
+	mockPantryRepo.EXPECT().
+		ConsumePantryEntry(ctx, testPantryID, "1", 3.0).
+		Return(false, repository.ErrInsufficientQuantity).
+		Times(1)
+
+	result, err := usecaseInstance.ConsumePantryEntry(ctx, testPantryID, "1", 3, nil)
+
+	assert.ErrorIs(t, err, repository.ErrInsufficientQuantity)
+	assert.Nil(t, result)
+}
+
+func TestConsumePantryEntry_IncompatibleUnit(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	entries := []entity.PantryEntry{
+		{ID: "1", Name: "Apples", Quantity: float64Ptr(2)},
+	}
+	expectMemberWithEntries(ctx, entity.PantryRoleEditor, entries)
+
+	result, err := usecaseInstance.ConsumePantryEntry(ctx, testPantryID, "1", 1, stringPtr("cup"))
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
 // Helper functions
 func float64Ptr(f float64) *float64 {
 	return &f
 }
 
+func stringPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
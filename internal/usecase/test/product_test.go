@@ -0,0 +1,120 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/barcode"
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+)
+
+func TestInsertPantryEntryByBarcode(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	dairy := entity.CategoryDairy
+	product := &entity.Product{
+		Code:        "0036000291452",
+		Name:        "whole milk",
+		DefaultUnit: stringPtr("l"),
+		PackageSize: float64Ptr(1),
+		Category:    &dairy,
+	}
+
+	mockProductRepo.EXPECT().GetProduct(ctx, "0036000291452").Return(product, nil).Times(1)
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockPantryRepo.EXPECT().
+		InsertPantryEntry(ctx, testPantryID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entry *entity.PantryEntry) error {
+			assert.Equal(t, "whole milk", entry.Name)
+			assert.Equal(t, 1.0, *entry.Quantity)
+			assert.Equal(t, "l", *entry.QuantityType)
+			assert.Equal(t, entity.CategoryDairy, *entry.Category)
+			return nil
+		}).
+		Times(1)
+
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+	// UPC-A scans resolve to the EAN-13 catalog key
+	entry, err := usecaseInstance.InsertPantryEntryByBarcode(ctx, testPantryID, "036000291452")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, entry.ID)
+	assert.Equal(t, "whole milk", entry.Name)
+}
+
+func TestInsertPantryEntryByBarcode_InvalidCode(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	_, err := usecaseInstance.InsertPantryEntryByBarcode(callerContext(testUserID), testPantryID, "036000291453")
+
+	assert.ErrorIs(t, err, barcode.ErrInvalidCheckDigit)
+}
+
+func TestInsertPantryEntryByBarcode_UnknownProduct(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockProductRepo.EXPECT().
+		GetProduct(ctx, "4006381333931").
+		Return(nil, fmt.Errorf("%w: %s", repository.ErrProductNotFound, "4006381333931")).
+		Times(1)
+
+	_, err := usecaseInstance.InsertPantryEntryByBarcode(ctx, testPantryID, "4006381333931")
+
+	assert.ErrorIs(t, err, repository.ErrProductNotFound)
+}
+
+func TestImportProductsCSV(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := context.Background()
+	csv := strings.Join([]string{
+		"code,name,unit,package_size,category",
+		"4006381333931,Pencils,piece,12,",
+		"036000291452,Whole Milk,L,1,dairy",
+		"036000291453,Bad Check Digit,,,",
+		"96385074,,,,",
+		"4006381333931,Pencils,piece,-2,",
+		"4006381333931,Pencils,piece,1,stationery",
+	}, "\n")
+
+	var stored []*entity.Product
+	mockProductRepo.EXPECT().
+		UpsertProduct(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, product *entity.Product) error {
+			stored = append(stored, product)
+			return nil
+		}).
+		Times(2)
+
+	result, err := usecaseInstance.ImportProductsCSV(ctx, strings.NewReader(csv))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Len(t, result.Errors, 4)
+	assert.Equal(t, []int{3, 4, 5, 6}, []int{result.Errors[0].Row, result.Errors[1].Row, result.Errors[2].Row, result.Errors[3].Row})
+	assert.Equal(t, "0036000291452", stored[1].Code)
+	assert.Equal(t, "l", *stored[1].DefaultUnit)
+	assert.Equal(t, entity.CategoryDairy, *stored[1].Category)
+	assert.Nil(t, stored[0].Category)
+}
+
+func TestImportProductsCSV_MissingColumn(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	_, err := usecaseInstance.ImportProductsCSV(context.Background(), strings.NewReader("name,unit\nMilk,l\n"))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+)
+
+func TestRateRecipe_FirstRating(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(&entity.Recipe{ID: "recipe-1"}, nil).Times(1)
+	expectTransaction(ctx)
+	mockRatingRepo.EXPECT().UpsertRating(ctx, gomock.Any()).Return(nil, nil).Times(1)
+	mockRecipeRepo.EXPECT().AdjustRecipeRating(ctx, "recipe-1", 4, 1).Return(nil).Times(1)
+
+	rating, err := usecaseInstance.RateRecipe(ctx, "recipe-1", 4, stringPtr("  Lovely  "))
+
+	assert.NoError(t, err)
+	assert.Equal(t, testUserID, rating.UserID)
+	if assert.NotNil(t, rating.Note) {
+		assert.Equal(t, "Lovely", *rating.Note)
+	}
+}
+
+func TestRateRecipe_ReplacesEarlierRating(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	created := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(&entity.Recipe{ID: "recipe-1"}, nil).Times(1)
+	expectTransaction(ctx)
+	mockRatingRepo.EXPECT().
+		UpsertRating(ctx, gomock.Any()).
+		Return(&entity.RecipeRating{RecipeID: "recipe-1", UserID: testUserID, Stars: 5, CreatedAt: created}, nil).
+		Times(1)
+	mockRecipeRepo.EXPECT().AdjustRecipeRating(ctx, "recipe-1", -3, 0).Return(nil).Times(1)
+
+	rating, err := usecaseInstance.RateRecipe(ctx, "recipe-1", 2, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, created, rating.CreatedAt)
+}
+
+func TestRateRecipe_SameStarsLeavesAggregates(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(&entity.Recipe{ID: "recipe-1"}, nil).Times(1)
+	expectTransaction(ctx)
+	mockRatingRepo.EXPECT().
+		UpsertRating(ctx, gomock.Any()).
+		Return(&entity.RecipeRating{RecipeID: "recipe-1", UserID: testUserID, Stars: 3}, nil).
+		Times(1)
+
+	_, err := usecaseInstance.RateRecipe(ctx, "recipe-1", 3, stringPtr("Changed my note"))
+
+	assert.NoError(t, err)
+}
+
+func TestRateRecipe_AggregateFailureFailsRating(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(&entity.Recipe{ID: "recipe-1"}, nil).Times(1)
+	expectTransaction(ctx)
+	mockRatingRepo.EXPECT().UpsertRating(ctx, gomock.Any()).Return(nil, nil).Times(1)
+	mockRecipeRepo.EXPECT().AdjustRecipeRating(ctx, "recipe-1", 4, 1).Return(assert.AnError).Times(1)
+
+	rating, err := usecaseInstance.RateRecipe(ctx, "recipe-1", 4, nil)
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, rating)
+}
+
+func TestRateRecipe_OutOfRange(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	_, err := usecaseInstance.RateRecipe(callerContext(testUserID), "recipe-1", 6, nil)
+
+	assert.Error(t, err)
+}
+
+func TestMyRating_NotRated(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRatingRepo.EXPECT().
+		GetRating(ctx, "recipe-1", testUserID).
+		Return(nil, repository.ErrRatingNotFound).
+		Times(1)
+
+	rating, err := usecaseInstance.MyRating(ctx, "recipe-1")
+
+	assert.NoError(t, err)
+	assert.Nil(t, rating)
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/usecase"
+)
+
+var markdownRecipes = strings.Join([]string{
+	"# Garlic rice",
+	"Source: https://example.com/garlic-rice",
+	"## Ingredients",
+	"- 1 cup white rice",
+	"- 3 cloves garlic, minced",
+	"## Steps",
+	"1. Cook the rice.",
+	"",
+	"# Empty bowl",
+	"Nothing to see here.",
+}, "\n")
+
+func TestImportRecipes_ReportsFailuresPerRecipe(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().
+		CreateRecipe(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, recipe *entity.Recipe) error {
+			assert.NotEmpty(t, recipe.ID)
+			assert.Equal(t, testUserID, recipe.CreatedBy)
+			assert.Equal(t, "https://example.com/garlic-rice", *recipe.SourceUrl)
+			assert.Equal(t, "rice", recipe.Ingredients[0].IngredientID)
+			return nil
+		}).
+		Times(1)
+
+	result, err := usecaseInstance.ImportRecipes(ctx, strings.NewReader(markdownRecipes), entity.RecipeImportFormatMarkdown, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	assert.Len(t, result.Recipes, 1)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, 2, result.Errors[0].Index)
+		assert.Equal(t, "Empty bowl", *result.Errors[0].Name)
+		assert.Equal(t, "a recipe needs at least one ingredient", result.Errors[0].Message)
+	}
+}
+
+func TestImportRecipes_StoreFailureOnlyFailsThatRecipe(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	doc := strings.Join([]string{
+		"# Toast", "## Ingredients", "- 2 slices bread",
+		"# Tea", "## Ingredients", "- 1 tea bag",
+	}, "\n")
+	mockRecipeRepo.EXPECT().
+		CreateRecipe(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, recipe *entity.Recipe) error {
+			if recipe.Name == "Toast" {
+				return errors.New("write failed")
+			}
+			return nil
+		}).
+		Times(2)
+
+	result, err := usecaseInstance.ImportRecipes(ctx, strings.NewReader(doc), entity.RecipeImportFormatMarkdown, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "Toast", *result.Errors[0].Name)
+	}
+}
+
+func TestImportRecipes_DryRun(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	result, err := usecaseInstance.ImportRecipes(callerContext(testUserID), strings.NewReader(markdownRecipes), entity.RecipeImportFormatMarkdown, true)
+
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Zero(t, result.Imported)
+	assert.Len(t, result.Recipes, 1)
+	assert.Len(t, result.Errors, 1)
+}
+
+func TestImportRecipes_Unauthenticated(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	_, err := usecaseInstance.ImportRecipes(context.Background(), strings.NewReader(markdownRecipes), entity.RecipeImportFormatMarkdown, false)
+
+	assert.ErrorIs(t, err, usecase.ErrUnauthenticated)
+}
+
+func TestRecipeImportFormatFromFilename(t *testing.T) {
+	format, err := usecase.RecipeImportFormatFromFilename("shakshuka.HTML")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RecipeImportFormatJSONLd, format)
+
+	format, err = usecase.RecipeImportFormatFromFilename("cookies.mmf")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RecipeImportFormatMealmaster, format)
+
+	_, err = usecase.RecipeImportFormatFromFilename("recipes.pdf")
+	assert.Error(t, err)
+}
@@ -0,0 +1,264 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+func suggestionFixture() ([]entity.Recipe, []entity.PantryEntry) {
+	soon := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	later := soon.AddDate(0, 1, 0)
+	recipes := []entity.Recipe{
+		{
+			ID:   "carbonara",
+			Name: "Carbonara",
+			Ingredients: []entity.RecipeIngredient{
+				{Name: "pasta", IngredientID: "pasta", Quantity: float64Ptr(400), Unit: stringPtr("g")},
+				{Name: "eggs", IngredientID: "egg", Quantity: float64Ptr(3)},
+				{Name: "bacon", IngredientID: "bacon", Quantity: float64Ptr(150), Unit: stringPtr("g")},
+				{Name: "black pepper", IngredientID: "black_pepper", Quantity: float64Ptr(1), Unit: stringPtr("tsp")},
+				{Name: "parsley", IngredientID: "parsley", Optional: true},
+			},
+		},
+		{
+			// Stored without canonical IDs, so its ingredients resolve by name.
+			ID:   "omelette",
+			Name: "Omelette",
+			Ingredients: []entity.RecipeIngredient{
+				{Name: "Eggs", Quantity: float64Ptr(3)},
+				{Name: "salt", Preparation: stringPtr("to taste")},
+			},
+		},
+		{
+			ID:   "curry",
+			Name: "Curry",
+			Ingredients: []entity.RecipeIngredient{
+				{Name: "chicken", Quantity: float64Ptr(500), Unit: stringPtr("g")},
+				{Name: "rice", Quantity: float64Ptr(1), Unit: stringPtr("cup")},
+				{Name: "coconut milk", Quantity: float64Ptr(1), Unit: stringPtr("can")},
+			},
+		},
+	}
+	entries := []entity.PantryEntry{
+		{ID: "1", Name: "Salt", IngredientID: "salt"},
+		{ID: "2", Name: "Black Pepper", IngredientID: "black_pepper"},
+		{ID: "3", Name: "Eggs", IngredientID: "egg", Expiration: &later},
+		{ID: "4", Name: "egg", IngredientID: "egg", Expiration: &soon},
+		{ID: "5", Name: "spaghetti"},
+	}
+	return recipes, entries
+}
+
+func TestGenerateRecipesFromPantry_RanksByCoverage(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	recipes, entries := suggestionFixture()
+	expectMemberWithEntries(ctx, entity.PantryRoleViewer, entries)
+	mockRecipeRepo.EXPECT().GetRecipes(ctx).Return(recipes, nil).Times(1)
+
+	suggestions, err := usecaseInstance.GenerateRecipesFromPantry(ctx, testPantryID, nil, nil, false)
+
+	assert.NoError(t, err)
+	if assert.Len(t, suggestions, 2) {
+		assert.Equal(t, "omelette", suggestions[0].Recipe.ID)
+		assert.Equal(t, 1.0, suggestions[0].Coverage)
+		assert.Empty(t, suggestions[0].MissingIngredients)
+		if assert.Len(t, suggestions[0].UsedEntries, 2) {
+			// The egg expiring first is the one to use.
+			assert.Equal(t, "4", suggestions[0].UsedEntries[0].ID)
+			assert.Equal(t, "1", suggestions[0].UsedEntries[1].ID)
+		}
+
+		assert.Equal(t, "carbonara", suggestions[1].Recipe.ID)
+		assert.Equal(t, 0.5, suggestions[1].Coverage)
+		// The optional parsley is neither missing nor part of the coverage.
+		assert.Equal(t, []string{"bacon", "pasta"}, suggestions[1].MissingIngredients)
+	}
+}
+
+func TestGenerateRecipesFromPantry_Filters(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	recipes, entries := suggestionFixture()
+	expectMemberWithEntries(ctx, entity.PantryRoleViewer, entries)
+	mockRecipeRepo.EXPECT().GetRecipes(ctx).Return(recipes, nil).Times(1)
+
+	maxMissing := 1
+	suggestions, err := usecaseInstance.GenerateRecipesFromPantry(ctx, testPantryID, &maxMissing, nil, false)
+
+	assert.NoError(t, err)
+	if assert.Len(t, suggestions, 1) {
+		assert.Equal(t, "omelette", suggestions[0].Recipe.ID)
+	}
+}
+
+func TestGenerateRecipesFromPantry_InvalidCoverage(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	minCoverage := 1.5
+	_, err := usecaseInstance.GenerateRecipesFromPantry(callerContext(testUserID), testPantryID, nil, &minCoverage, false)
+
+	assert.Error(t, err)
+}
+
+func TestCreateRecipe(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().CreateRecipe(ctx, gomock.Any()).Return(nil).Times(1)
+
+	recipe, err := usecaseInstance.CreateRecipe(ctx, &entity.RecipeInput{
+		Name: "  Pancakes ",
+		Ingredients: []*entity.RecipeIngredientInput{
+			{Name: "Plain Flour", Quantity: float64Ptr(200), Unit: stringPtr("g")},
+			{Name: "eggs", Quantity: float64Ptr(2)},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, recipe.ID)
+	assert.Equal(t, "Pancakes", recipe.Name)
+	assert.Equal(t, testUserID, recipe.CreatedBy)
+	if assert.Len(t, recipe.Ingredients, 2) {
+		assert.Equal(t, "flour", recipe.Ingredients[0].IngredientID)
+		assert.Equal(t, "egg", recipe.Ingredients[1].IngredientID)
+	}
+}
+
+func TestCreateRecipe_Validation(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	difficulty := 6
+	inputs := map[string]*entity.RecipeInput{
+		"missing name":   {Name: " ", Ingredients: []*entity.RecipeIngredientInput{{Name: "egg"}}},
+		"no ingredients": {Name: "Air"},
+		"difficulty":     {Name: "Soufflé", Difficulty: &difficulty, Ingredients: []*entity.RecipeIngredientInput{{Name: "egg"}}},
+		"unit only":      {Name: "Toast", Ingredients: []*entity.RecipeIngredientInput{{Name: "bread", Unit: stringPtr("slices")}}},
+	}
+
+	for name, input := range inputs {
+		_, err := usecaseInstance.CreateRecipe(ctx, input)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestUpdateRecipe_SeededRecipe(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().
+		GetRecipe(ctx, "recipe-1").
+		Return(&entity.Recipe{ID: "recipe-1", RatingCount: 2, RatingTotal: 9}, nil).
+		Times(1)
+	mockRecipeRepo.EXPECT().UpdateRecipe(ctx, gomock.Any()).Return(nil).Times(1)
+
+	recipe, err := usecaseInstance.UpdateRecipe(ctx, "recipe-1", &entity.RecipeInput{
+		Name:        "Toast",
+		Ingredients: []*entity.RecipeIngredientInput{{Name: "bread"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", recipe.CreatedBy)
+	assert.Equal(t, 2, recipe.RatingCount)
+}
+
+func TestDeleteRecipe(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().
+		GetRecipe(ctx, "recipe-1").
+		Return(&entity.Recipe{ID: "recipe-1", CreatedBy: testUserID}, nil).
+		Times(1)
+	expectTransaction(ctx)
+	mockRecipeRepo.EXPECT().DeleteRecipe(ctx, "recipe-1").Return(nil).Times(1)
+	mockRatingRepo.EXPECT().DeleteRatings(ctx, "recipe-1").Return(nil).Times(1)
+
+	err := usecaseInstance.DeleteRecipe(ctx, "recipe-1")
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteRecipe_RatingsFailure(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().
+		GetRecipe(ctx, "recipe-1").
+		Return(&entity.Recipe{ID: "recipe-1"}, nil).
+		Times(1)
+	expectTransaction(ctx)
+	mockRecipeRepo.EXPECT().DeleteRecipe(ctx, "recipe-1").Return(nil).Times(1)
+	mockRatingRepo.EXPECT().DeleteRatings(ctx, "recipe-1").Return(assert.AnError).Times(1)
+
+	err := usecaseInstance.DeleteRecipe(ctx, "recipe-1")
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestCreateRecipe_Steps(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().CreateRecipe(ctx, gomock.Any()).Return(nil).Times(1)
+	five, thirty := 5, 30
+
+	recipe, err := usecaseInstance.CreateRecipe(ctx, &entity.RecipeInput{
+		Name: "Rice",
+		Ingredients: []*entity.RecipeIngredientInput{
+			{Name: "White Rice", Quantity: float64Ptr(1), Unit: stringPtr("cup")},
+			{Name: "salt", Preparation: stringPtr("a pinch")},
+		},
+		Steps: []*entity.RecipeStepInput{
+			{Text: "Rinse the rice", DurationMinutes: &five, Ingredients: []string{"white rice"}},
+			{Text: "Simmer covered", DurationMinutes: &thirty, Ingredients: []string{"rice", "Salt"}, Passive: true},
+		},
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, recipe.Steps, 2) {
+		assert.Equal(t, []string{"rice"}, recipe.Steps[0].IngredientIDs)
+		assert.Equal(t, []string{"rice", "salt"}, recipe.Steps[1].IngredientIDs)
+	}
+	assert.Equal(t, 35, *recipe.TotalTime())
+	assert.Equal(t, 5, *recipe.ActiveTime())
+}
+
+func TestCreateRecipe_StepWithUnknownIngredient(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	_, err := usecaseInstance.CreateRecipe(callerContext(testUserID), &entity.RecipeInput{
+		Name:        "Toast",
+		Ingredients: []*entity.RecipeIngredientInput{{Name: "bread"}},
+		Steps:       []*entity.RecipeStepInput{{Text: "Butter the toast", Ingredients: []string{"butter"}}},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestRecipeTotalTime_FallsBackToPrepAndCook(t *testing.T) {
+	prep, cook := 30, 45
+	recipe := &entity.Recipe{PrepTime: &prep, CookTime: &cook, Steps: []entity.RecipeStep{{Text: "Bake"}}}
+
+	assert.Equal(t, 75, *recipe.TotalTime())
+	assert.Nil(t, recipe.ActiveTime())
+	assert.Nil(t, (&entity.Recipe{}).TotalTime())
+}
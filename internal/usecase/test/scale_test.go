@@ -0,0 +1,56 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+func TestScaleRecipe(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	servings := 8
+	mockRecipeRepo.EXPECT().
+		GetRecipe(ctx, "recipe-1").
+		Return(&entity.Recipe{
+			ID:       "recipe-1",
+			Servings: &servings,
+			Ingredients: []entity.RecipeIngredient{
+				{Name: "apples", Quantity: float64Ptr(5)},
+				{Name: "cinnamon", Quantity: float64Ptr(1), Unit: stringPtr("tsp")},
+				{Name: "cheese", Quantity: float64Ptr(4), Unit: stringPtr("slices")},
+				{Name: "salt", Preparation: stringPtr("to taste")},
+			},
+		}, nil).
+		Times(1)
+
+	recipe, err := usecaseInstance.ScaleRecipe(ctx, "recipe-1", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *recipe.Servings)
+	if assert.Len(t, recipe.Ingredients, 4) {
+		assert.Equal(t, 1.0, *recipe.Ingredients[0].Quantity)
+		assert.Nil(t, recipe.Ingredients[0].Unit)
+		assert.Equal(t, 0.25, *recipe.Ingredients[1].Quantity)
+		assert.Equal(t, "tsp", *recipe.Ingredients[1].Unit)
+		assert.Equal(t, 1.0, *recipe.Ingredients[2].Quantity)
+		assert.Equal(t, "slices", *recipe.Ingredients[2].Unit)
+		assert.Nil(t, recipe.Ingredients[3].Quantity)
+	}
+}
+
+func TestScaleRecipe_NoBaseServings(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().GetRecipe(ctx, "recipe-1").Return(&entity.Recipe{ID: "recipe-1"}, nil).Times(1)
+
+	_, err := usecaseInstance.ScaleRecipe(ctx, "recipe-1", 4)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+)
+
+func TestSearchRecipes_PagesThroughStoreResults(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	limit := 1
+	mockRecipeRepo.EXPECT().
+		SearchRecipes(ctx, "soup", 0, 2).
+		Return([]entity.RecipeSearchHit{{Recipe: entity.Recipe{ID: "a"}, Score: 2}, {Recipe: entity.Recipe{ID: "b"}, Score: 1}}, nil).
+		Times(1)
+
+	page, err := usecaseInstance.SearchRecipes(ctx, " soup ", &limit, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, page.HasNextPage)
+	if assert.Len(t, page.Hits, 1) && assert.NotNil(t, page.EndCursor) {
+		assert.Equal(t, "a", page.Hits[0].Recipe.ID)
+
+		mockRecipeRepo.EXPECT().
+			SearchRecipes(ctx, "soup", 1, 2).
+			Return([]entity.RecipeSearchHit{{Recipe: entity.Recipe{ID: "b"}, Score: 1}}, nil).
+			Times(1)
+
+		next, err := usecaseInstance.SearchRecipes(ctx, "soup", &limit, page.EndCursor)
+
+		assert.NoError(t, err)
+		assert.False(t, next.HasNextPage)
+		if assert.Len(t, next.Hits, 1) {
+			assert.Equal(t, "b", next.Hits[0].Recipe.ID)
+		}
+	}
+}
+
+func TestSearchRecipes_FallsBackToInMemorySearch(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	mockRecipeRepo.EXPECT().
+		SearchRecipes(ctx, "tomato", 0, 21).
+		Return(nil, repository.ErrSearchUnsupported).
+		Times(1)
+	mockRecipeRepo.EXPECT().
+		GetRecipes(ctx).
+		Return([]entity.Recipe{
+			{ID: "1", Name: "Pancakes"},
+			{ID: "2", Name: "Tomato Soup"},
+			{ID: "3", Name: "Bruschetta", Ingredients: []entity.RecipeIngredient{{Name: "tomatoes"}}},
+		}, nil).
+		Times(1)
+
+	page, err := usecaseInstance.SearchRecipes(ctx, "tomato", nil, nil)
+
+	assert.NoError(t, err)
+	assert.False(t, page.HasNextPage)
+	if assert.Len(t, page.Hits, 2) {
+		assert.Equal(t, "2", page.Hits[0].Recipe.ID)
+		assert.Equal(t, "3", page.Hits[1].Recipe.ID)
+	}
+}
+
+func TestSearchRecipes_InvalidCursor(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	cursor := "not-a-cursor"
+	_, err := usecaseInstance.SearchRecipes(callerContext(testUserID), "soup", nil, &cursor)
+
+	assert.Error(t, err)
+}
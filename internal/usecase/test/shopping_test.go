@@ -0,0 +1,153 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+	"github.com/thisausername99/pantry_butler/internal/domain/repository"
+	"github.com/thisausername99/pantry_butler/internal/usecase"
+)
+
+var testListID = "testListID"
+
+// expectShoppingList returns a list in testPantryID holding one unchecked item.
+func expectShoppingList(ctx context.Context) {
+	mockListRepo.EXPECT().
+		GetShoppingList(ctx, testListID).
+		Return(&entity.ShoppingList{
+			ID:       testListID,
+			PantryID: testPantryID,
+			Name:     "Groceries",
+			Items: []entity.ShoppingListItem{
+				{ID: "item-1", Name: "milk", Quantity: float64Ptr(2), Unit: stringPtr("l")},
+			},
+		}, nil).
+		Times(1)
+}
+
+func TestAddShoppingListItem(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectShoppingList(ctx)
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockListRepo.EXPECT().AddShoppingListItem(ctx, testListID, gomock.Any()).Return(nil).Times(1)
+
+	item, err := usecaseInstance.AddShoppingListItem(ctx, testListID, &entity.ShoppingListItemInput{Name: " eggs ", Quantity: float64Ptr(12)})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, item.ID)
+	assert.Equal(t, "eggs", item.Name)
+	assert.Equal(t, testUserID, item.AddedBy)
+	assert.False(t, item.Checked)
+}
+
+func TestAddShoppingListItem_ViewerForbidden(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectShoppingList(ctx)
+	expectMember(ctx, entity.PantryRoleViewer)
+
+	_, err := usecaseInstance.AddShoppingListItem(ctx, testListID, &entity.ShoppingListItemInput{Name: "eggs"})
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestCheckShoppingListItem_AddsToPantry(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectTransaction(ctx)
+	expectShoppingList(ctx)
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockListRepo.EXPECT().SetShoppingListItemChecked(ctx, testListID, "item-1", true, gomock.Any()).Return(true, nil).Times(1)
+	// insertPantryEntry authorizes against the pantry again
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockPantryRepo.EXPECT().
+		InsertPantryEntry(ctx, testPantryID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entry *entity.PantryEntry) error {
+			assert.Equal(t, "milk", entry.Name)
+			assert.Equal(t, 2.0, *entry.Quantity)
+			assert.Equal(t, "l", *entry.QuantityType)
+			return nil
+		}).
+		Times(1)
+	expectHistory(ctx, entity.HistoryActionEntryInserted)
+
+	result, err := usecaseInstance.CheckShoppingListItem(ctx, testListID, "item-1", true, &entity.AddToPantryInput{})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Item.Checked)
+	assert.NotNil(t, result.Item.CheckedAt)
+	assert.Equal(t, "milk", result.Entry.Name)
+}
+
+func TestCheckShoppingListItem_AlreadyCheckedDoesNotAddTwice(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectTransaction(ctx)
+	expectShoppingList(ctx)
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockListRepo.EXPECT().SetShoppingListItemChecked(ctx, testListID, "item-1", true, gomock.Any()).Return(false, nil).Times(1)
+
+	result, err := usecaseInstance.CheckShoppingListItem(ctx, testListID, "item-1", true, &entity.AddToPantryInput{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.Entry)
+}
+
+func TestCheckShoppingListItem_InsertFailureFailsCheck(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectTransaction(ctx)
+	expectShoppingList(ctx)
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockListRepo.EXPECT().SetShoppingListItemChecked(ctx, testListID, "item-1", true, gomock.Any()).Return(true, nil).Times(1)
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockPantryRepo.EXPECT().InsertPantryEntry(ctx, testPantryID, gomock.Any()).Return(errors.New("db down")).Times(1)
+
+	_, err := usecaseInstance.CheckShoppingListItem(ctx, testListID, "item-1", true, &entity.AddToPantryInput{})
+
+	assert.Error(t, err)
+}
+
+func TestCheckShoppingListItem_UnknownItem(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectTransaction(ctx)
+	expectShoppingList(ctx)
+	expectMember(ctx, entity.PantryRoleEditor)
+
+	_, err := usecaseInstance.CheckShoppingListItem(ctx, testListID, "item-2", true, &entity.AddToPantryInput{})
+
+	assert.ErrorIs(t, err, repository.ErrShoppingItemNotFound)
+}
+
+func TestClearShoppingList(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	expectShoppingList(ctx)
+	expectMember(ctx, entity.PantryRoleEditor)
+	mockListRepo.EXPECT().ClearShoppingListItems(ctx, testListID, true).Return(nil).Times(1)
+
+	err := usecaseInstance.ClearShoppingList(ctx, testListID, true)
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,152 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thisausername99/pantry_butler/internal/domain/entity"
+)
+
+func substitutionFixture() ([]entity.Recipe, []entity.PantryEntry, []entity.Substitution) {
+	recipes := []entity.Recipe{
+		{
+			ID:   "shortbread",
+			Name: "Shortbread",
+			Ingredients: []entity.RecipeIngredient{
+				{Name: "flour", IngredientID: "flour", Quantity: float64Ptr(300), Unit: stringPtr("g")},
+				{Name: "butter", IngredientID: "butter", Quantity: float64Ptr(0.5), Unit: stringPtr("cup")},
+			},
+		},
+		{
+			ID:   "scones",
+			Name: "Scones",
+			Ingredients: []entity.RecipeIngredient{
+				{Name: "flour", IngredientID: "flour", Quantity: float64Ptr(250), Unit: stringPtr("g")},
+				{Name: "buttermilk", IngredientID: "buttermilk", Quantity: float64Ptr(1), Unit: stringPtr("cup")},
+			},
+		},
+	}
+	entries := []entity.PantryEntry{
+		{ID: "1", Name: "Flour", IngredientID: "flour"},
+		{ID: "2", Name: "Margarine", IngredientID: "margarine"},
+		{ID: "3", Name: "Milk", IngredientID: "milk"},
+	}
+	rules := []entity.Substitution{
+		{
+			ID: "butter_margarine", Name: "butter", IngredientID: "butter", Quantity: 1, Unit: stringPtr("cup"),
+			Replacement: []entity.SubstitutionPart{
+				{Name: "margarine", IngredientID: "margarine", Quantity: 1, Unit: stringPtr("cup")},
+			},
+		},
+		{
+			ID: "buttermilk_milk_lemon", Name: "buttermilk", IngredientID: "buttermilk", Quantity: 1, Unit: stringPtr("cup"),
+			Replacement: []entity.SubstitutionPart{
+				{Name: "milk", IngredientID: "milk", Quantity: 1, Unit: stringPtr("cup")},
+				{Name: "lemon juice", IngredientID: "lemon_juice", Quantity: 1, Unit: stringPtr("tbsp")},
+			},
+		},
+	}
+	return recipes, entries, rules
+}
+
+func TestGenerateRecipesFromPantry_UsesSubstitutes(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	recipes, entries, rules := substitutionFixture()
+	expectMemberWithEntries(ctx, entity.PantryRoleViewer, entries)
+	mockRecipeRepo.EXPECT().GetRecipes(ctx).Return(recipes, nil).Times(1)
+	mockSubstRepo.EXPECT().GetSubstitutions(ctx, []string{"butter", "buttermilk"}).Return(rules, nil).Times(1)
+
+	suggestions, err := usecaseInstance.GenerateRecipesFromPantry(ctx, testPantryID, nil, nil, true)
+
+	assert.NoError(t, err)
+	if !assert.Len(t, suggestions, 2) {
+		return
+	}
+	assert.Equal(t, "shortbread", suggestions[0].Recipe.ID)
+	assert.Equal(t, 1.0, suggestions[0].Coverage)
+	assert.Empty(t, suggestions[0].MissingIngredients)
+	assert.Len(t, suggestions[0].UsedEntries, 2)
+	if assert.Len(t, suggestions[0].Substitutions, 1) {
+		applied := suggestions[0].Substitutions[0]
+		assert.Equal(t, "butter", applied.Ingredient)
+		assert.Equal(t, "butter_margarine", applied.Rule.ID)
+		if assert.Len(t, applied.Replacement, 1) {
+			// Half a cup of butter takes half a cup of margarine.
+			assert.Equal(t, 0.5, applied.Replacement[0].Quantity)
+			assert.Equal(t, "cup", *applied.Replacement[0].Unit)
+		}
+	}
+
+	// Milk alone is not enough to stand in for buttermilk.
+	assert.Equal(t, "scones", suggestions[1].Recipe.ID)
+	assert.Equal(t, []string{"buttermilk"}, suggestions[1].MissingIngredients)
+	assert.Empty(t, suggestions[1].Substitutions)
+}
+
+func TestGenerateRecipesFromPantry_SubstitutesOff(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	recipes, entries, _ := substitutionFixture()
+	expectMemberWithEntries(ctx, entity.PantryRoleViewer, entries)
+	mockRecipeRepo.EXPECT().GetRecipes(ctx).Return(recipes, nil).Times(1)
+
+	suggestions, err := usecaseInstance.GenerateRecipesFromPantry(ctx, testPantryID, nil, nil, false)
+
+	assert.NoError(t, err)
+	for _, suggestion := range suggestions {
+		assert.Empty(t, suggestion.Substitutions)
+		assert.Len(t, suggestion.MissingIngredients, 1)
+	}
+}
+
+func TestSubstitutions_ResolvesIngredientName(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	_, _, rules := substitutionFixture()
+	mockSubstRepo.EXPECT().GetSubstitutions(ctx, []string{"butter"}).Return(rules[:1], nil).Times(1)
+
+	result, err := usecaseInstance.Substitutions(ctx, "Butter")
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+}
+
+func TestGenerateRecipesFromPantry_AppliesMultiPartSubstitute(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	ctx := callerContext(testUserID)
+	recipes, entries, rules := substitutionFixture()
+	entries = append(entries, entity.PantryEntry{ID: "4", Name: "Lemon juice", IngredientID: "lemon_juice"})
+	expectMemberWithEntries(ctx, entity.PantryRoleViewer, entries)
+	mockRecipeRepo.EXPECT().GetRecipes(ctx).Return(recipes[1:], nil).Times(1)
+	mockSubstRepo.EXPECT().GetSubstitutions(ctx, []string{"buttermilk"}).Return(rules[1:], nil).Times(1)
+
+	suggestions, err := usecaseInstance.GenerateRecipesFromPantry(ctx, testPantryID, nil, nil, true)
+
+	assert.NoError(t, err)
+	if !assert.Len(t, suggestions, 1) {
+		return
+	}
+	assert.Equal(t, 1.0, suggestions[0].Coverage)
+	assert.Len(t, suggestions[0].UsedEntries, 3)
+	if !assert.Len(t, suggestions[0].Substitutions, 1) {
+		return
+	}
+	replacement := suggestions[0].Substitutions[0].Replacement
+	if assert.Len(t, replacement, 2) {
+		assert.Equal(t, "milk", replacement[0].Name)
+		assert.Equal(t, 1.0, replacement[0].Quantity)
+		assert.Equal(t, "cup", *replacement[0].Unit)
+		assert.Equal(t, "lemon juice", replacement[1].Name)
+		assert.Equal(t, "tbsp", *replacement[1].Unit)
+	}
+}
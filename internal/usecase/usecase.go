@@ -6,9 +6,17 @@ import (
 )
 
 type RepoWrapper struct {
-	RecipeRepo repo.RecipeRepository
-	PantryRepo repo.PantryRepository
-	UserRepo   repo.UserRepository
+	RecipeRepo       repo.RecipeRepository
+	PantryRepo       repo.PantryRepository
+	UserRepo         repo.UserRepository
+	InviteRepo       repo.InviteRepository
+	ProductRepo      repo.ProductRepository
+	ShoppingListRepo repo.ShoppingListRepository
+	MealPlanRepo     repo.MealPlanRepository
+	HistoryRepo      repo.HistoryRepository
+	RatingRepo       repo.RatingRepository
+	SubstitutionRepo repo.SubstitutionRepository
+	Transactor       repo.Transactor
 	// Add more repositories as needed
 }
 
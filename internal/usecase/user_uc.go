@@ -63,11 +63,14 @@ func (u *Usecase) RegisterUser(ctx context.Context, input *entity.UserRegisterIn
 
 func (u *Usecase) UpdateUserWithPantry(ctx context.Context, userID string, name string) error {
 	entries := &[]entity.PantryEntry{}
+	now := time.Now()
 	newPantry := &entity.Pantry{
 		ID:        uuid.New().String(),
 		Name:      name,
 		OwnerID:   userID,
-		CreatedAt: time.Now(),
+		Members:   []entity.PantryMember{{UserID: userID, Role: entity.PantryRoleOwner, AddedAt: now}},
+		Locations: defaultLocations(),
+		CreatedAt: now,
 		Entries:   entries,
 	}
 	err := u.RepoWrapper.PantryRepo.CreateNewPantry(ctx, newPantry)
@@ -78,19 +81,36 @@ func (u *Usecase) UpdateUserWithPantry(ctx context.Context, userID string, name
 	if err != nil {
 		return err
 	}
-	return nil
+	return u.recordHistory(ctx, newPantry.ID, entity.HistoryActionPantryCreated, nil, nil, nil)
 }
 
+// RemoveUserPantry deletes a pantry. Only its owner may do so, and the pantry
+// is detached from every member, not just the owner, in the same transaction
+// as the delete.
 func (u *Usecase) RemoveUserPantry(ctx context.Context, userID string, pantryID string) error {
-	err := u.RepoWrapper.UserRepo.DeletePantryFromUser(ctx, userID, pantryID)
+	pantry, err := u.authorize(ctx, pantryID, entity.PantryRoleOwner)
 	if err != nil {
-		u.Logger.Error("error deleting pantry from user", zap.Error(err))
 		return err
 	}
-	err = u.RepoWrapper.PantryRepo.DeletePantry(ctx, pantryID)
-	if err != nil {
-		u.Logger.Error("error deleting pantry", zap.Error(err))
-		return err
+	memberIDs := []string{userID}
+	seen := map[string]bool{userID: true}
+	for _, member := range append(pantry.Members, entity.PantryMember{UserID: pantry.OwnerID}) {
+		if member.UserID != "" && !seen[member.UserID] {
+			seen[member.UserID] = true
+			memberIDs = append(memberIDs, member.UserID)
+		}
 	}
-	return nil
+	return u.RepoWrapper.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := u.RepoWrapper.PantryRepo.DeletePantry(ctx, pantryID); err != nil {
+			u.Logger.Error("error deleting pantry", zap.Error(err))
+			return err
+		}
+		for _, memberID := range memberIDs {
+			if err := u.RepoWrapper.UserRepo.DeletePantryFromUser(ctx, memberID, pantryID); err != nil {
+				u.Logger.Error("error deleting pantry from user", zap.Error(err))
+				return err
+			}
+		}
+		return u.recordHistory(ctx, pantryID, entity.HistoryActionPantryDeleted, nil, nil, nil)
+	})
 }